@@ -4,6 +4,8 @@ import (
 	"context"
 	"lingo/utils"
 	"log"
+	// The runtime image has no timezone database for learners' streak timezones
+	_ "time/tzdata"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
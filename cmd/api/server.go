@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
 	"lingo/internal/handlers"
+	"lingo/internal/middleware"
+	"lingo/pkg/auth/rbac"
 	"lingo/pkg/auth/tokengen"
 	"lingo/utils"
 	"log"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -17,10 +20,28 @@ type Server struct {
 	router *gin.Engine
 }
 
-func dummy(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Hello",
-	})
+// newTokenMaker builds the token maker selected by TOKEN_MAKER: "public" signs
+// tokens with an Ed25519 key pair, anything else uses the symmetric PASETO_SECRET
+func newTokenMaker(config utils.Config) (tokengen.Maker, error) {
+	if config.TokenMaker != "public" {
+		return tokengen.NewPasetoMaker(config.PasetoSecret, config.TokenClockSkew)
+	}
+
+	var privateKey ed25519.PrivateKey
+	if config.PasetoPrivateKeyFile != "" {
+		key, err := tokengen.LoadPrivateKey(config.PasetoPrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		privateKey = key
+	}
+
+	publicKeys, err := tokengen.LoadPublicKeys(config.PasetoPublicKeyFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokengen.NewPublicKeyMaker(config.PasetoKeyID, privateKey, publicKeys, config.TokenClockSkew)
 }
 
 func NewServer(pool *pgxpool.Pool, config utils.Config) *Server {
@@ -38,57 +59,158 @@ func NewServer(pool *pgxpool.Pool, config utils.Config) *Server {
 
 	// Initialize handlers
 	sqlStore := db.NewSQLStore(pool)
-	newTok, err := tokengen.NewPasetoMaker(config.PasetoSecret)
+	newTok, err := newTokenMaker(config)
 	if err != nil {
 		log.Fatal("Couldn't create token maker", err)
 	}
-	adminHandler := handlers.NewAdminHandler(sqlStore.(*db.SQLStore), newTok)
+	mailer := utils.NewGmailSender("Lingo", config.EmailAddr, config.GmailKey)
+	policy, err := utils.NewPasswordPolicy(config.PasswordMinLength, config.BreachedPasswordsFile)
+	if err != nil {
+		log.Fatal("Couldn't load password policy", err)
+	}
+	twoFactorPolicy, err := rbac.NewTwoFactorPolicy(config.Admin2FARequiredRoles)
+	if err != nil {
+		log.Fatal("Couldn't load two-factor policy", err)
+	}
+	adminHandler := handlers.NewAdminHandler(sqlStore.(*db.SQLStore), newTok, mailer, config, policy, twoFactorPolicy)
+	learnerHandler := handlers.NewLearnerHandler(sqlStore.(*db.SQLStore), newTok, mailer, config, policy)
+	enrollmentHandler := handlers.NewEnrollmentHandler(sqlStore.(*db.SQLStore))
+	exerciseHandler := handlers.NewExerciseHandler(sqlStore.(*db.SQLStore))
+	streakHandler := handlers.NewStreakHandler(sqlStore.(*db.SQLStore))
+	xpHandler := handlers.NewXPHandler(sqlStore.(*db.SQLStore))
+	courseMapHandler := handlers.NewCourseMapHandler(sqlStore.(*db.SQLStore))
+	reviewHandler := handlers.NewReviewHandler(sqlStore.(*db.SQLStore))
+	vocabularyHandler := handlers.NewVocabularyHandler(sqlStore.(*db.SQLStore))
+	leagueHandler := handlers.NewLeagueHandler(sqlStore.(*db.SQLStore))
+	leaderboardHandler := handlers.NewLeaderboardHandler(sqlStore.(*db.SQLStore))
+	go leaderboardHandler.RefreshPeriodically(context.Background(), config.LeaderboardRefreshInterval)
 
 	public := router.Group("/v1/lingo")
 
 	// Authentication routes
-	public.POST("/auth/learner/signup", dummy)
-	public.POST("/auth/learner/login", dummy)
-	public.POST("/auth/learner/refresh", dummy)
+	public.POST("/auth/learner/signup", learnerHandler.RegisterLearner)
+	public.POST("/auth/learner/login", learnerHandler.LoginLearner)
+	public.POST("/auth/learner/refresh", learnerHandler.RefreshLearnerToken)
 	public.POST("/auth/admin/signup", adminHandler.RegisterAdmin)
 	public.POST("/auth/admin/login", adminHandler.LoginAdmin)
-	public.POST("/auth/admin/refresh", dummy)
+	public.POST("/auth/admin/login/2fa", adminHandler.VerifyAdminTwoFactorLogin)
+	public.POST("/auth/admin/refresh", adminHandler.RefreshAdminToken)
+	public.POST("/auth/learner/logout", learnerHandler.LogoutLearner)
+	public.POST("/auth/admin/logout", adminHandler.LogoutAdmin)
+	public.POST("/auth/learner/logout-all", middleware.AuthMiddleware(newTok, tokengen.RoleLearner), learnerHandler.LogoutAllLearnerDevices)
+	public.POST("/auth/admin/logout-all", middleware.AuthMiddleware(newTok, tokengen.RoleAdmin), adminHandler.LogoutAllAdminDevices)
+	public.POST("/auth/learner/verify-email", learnerHandler.VerifyLearnerEmail)
+	public.POST("/auth/learner/resend-verification", learnerHandler.ResendLearnerVerification)
+	public.POST("/auth/admin/verify-email", adminHandler.VerifyAdminEmail)
+	public.POST("/auth/admin/resend-verification", adminHandler.ResendAdminVerification)
+	public.POST("/auth/learner/forgot-password", learnerHandler.ForgotLearnerPassword)
+	public.POST("/auth/learner/reset-password", learnerHandler.ResetLearnerPassword)
+	public.POST("/auth/admin/forgot-password", adminHandler.ForgotAdminPassword)
+	public.POST("/auth/admin/reset-password", adminHandler.ResetAdminPassword)
+
+	// Authenticated route groups
+	adminAuth := middleware.AuthMiddleware(newTok, tokengen.RoleAdmin)
+	admin := public.Group("/admin", adminAuth, middleware.RequireTwoFactor(sqlStore, twoFactorPolicy))
+	// Admins can always manage their own two-factor authentication, even before meeting their role's policy
+	twoFactor := public.Group("/admin/2fa", adminAuth)
+	learnerAuth := middleware.AuthMiddleware(newTok, tokengen.RoleLearner)
+	learner := public.Group("/users", learnerAuth)
+	languages := public.Group("/languages", learnerAuth)
+	courses := public.Group("/courses", learnerAuth)
+	lessons := public.Group("/lessons", learnerAuth)
+	exercises := public.Group("/exercises", learnerAuth)
+	leaderboard := public.Group("/leaderboard", learnerAuth)
+	leagues := public.Group("/leagues", learnerAuth)
+	review := public.Group("/review", learnerAuth)
+
+	// can guards an admin route with a permission from the admin's role
+	can := func(permission rbac.Permission) gin.HandlerFunc {
+		return middleware.RequirePermission(sqlStore, permission)
+	}
+	self := middleware.RequireSelfOrPermission(sqlStore, "adminId", rbac.PermManageAdmins)
 
 	// Admin routes
-	public.PUT("/admin/details/:adminId", adminHandler.UpdateAdminDetails)
-	public.PUT("/admin/password/:adminId", adminHandler.UpdateAdminPassword)
+	admin.PUT("/details/:adminId", self, adminHandler.UpdateAdminDetails)
+	admin.PUT("/password", adminHandler.UpdateAdminPassword)
+	admin.GET("/admins/all", can(rbac.PermManageAdmins), adminHandler.ListAdmins)
+	admin.PUT("/admins/:adminId/role", can(rbac.PermManageAdmins), adminHandler.UpdateAdminRole)
+	admin.POST("/admins/invite", can(rbac.PermManageAdmins), adminHandler.InviteAdmin)
+	admin.POST("/admins/unlock", can(rbac.PermManageAdmins), adminHandler.UnlockAccount)
+
+	// Two-factor routes
+	twoFactor.GET("", adminHandler.GetTwoFactorStatus)
+	twoFactor.POST("/enroll", adminHandler.EnrollTwoFactor)
+	twoFactor.POST("/confirm", adminHandler.ConfirmTwoFactor)
+	twoFactor.POST("/recovery-codes", adminHandler.RegenerateRecoveryCodes)
+	twoFactor.POST("/disable", adminHandler.DisableTwoFactor)
 
 	// Language routes
-	public.POST("/admin/language/create", adminHandler.CreateNewLanguage)
-	public.PUT("/admin/language/:languageId", adminHandler.UpdateLanguageById)
-	public.DELETE("/admin/language/:languageId", adminHandler.DeleteLanguage)
-	public.GET("/admin/lesson/languages/all", adminHandler.GetAvailableLanguages)
+	admin.POST("/language/create", can(rbac.PermWriteContent), adminHandler.CreateNewLanguage)
+	admin.PUT("/language/:languageId", can(rbac.PermWriteContent), adminHandler.UpdateLanguageById)
+	admin.DELETE("/language/:languageId", can(rbac.PermDeleteLanguages), adminHandler.DeleteLanguage)
+	admin.GET("/lesson/languages/all", can(rbac.PermReadContent), adminHandler.GetAvailableLanguages)
 
 	// Course routes
-	public.POST("/admin/course/create/:langId", adminHandler.CreateNewCourse)
-	public.PUT("/admin/course/:courseId", adminHandler.UpdateCourseById)
-	public.DELETE("/admin/course/:courseId", adminHandler.DeleteCourse)
-	public.GET("/admin/lesson/courses/all", adminHandler.GetAllCourses)
+	admin.POST("/course/create/:langId", can(rbac.PermWriteContent), adminHandler.CreateNewCourse)
+	admin.PUT("/course/:courseId", can(rbac.PermWriteContent), adminHandler.UpdateCourseById)
+	admin.DELETE("/course/:courseId", can(rbac.PermDeleteCourses), adminHandler.DeleteCourse)
+	admin.PUT("/course/:courseId/publish", can(rbac.PermWriteContent), adminHandler.PublishCourse)
+	admin.GET("/lesson/courses/all", can(rbac.PermReadContent), adminHandler.GetAllCourses)
 
 	// Lesson routes
-	public.POST("/admin/lesson/create/:courseId", adminHandler.CreateNewLesson)
-	public.PUT("/admin/lesson/:lessonId", adminHandler.UpdateLessonById)
-	public.DELETE("/admin/lesson/:lessonId", adminHandler.DeleteLesson)
-	public.GET("/admin/lesson/lessons/all", adminHandler.GetAllLessons)
-	public.GET("/admin/lesson/lessons/by-course/:courseId", adminHandler.GetLessonsByCourseId)
+	admin.POST("/lesson/create/:courseId", can(rbac.PermWriteContent), adminHandler.CreateNewLesson)
+	admin.PUT("/lesson/:lessonId", can(rbac.PermWriteContent), adminHandler.UpdateLessonById)
+	admin.DELETE("/lesson/:lessonId", can(rbac.PermDeleteLessons), adminHandler.DeleteLesson)
+	admin.GET("/lesson/lessons/all", can(rbac.PermReadContent), adminHandler.GetAllLessons)
+	admin.GET("/lesson/lessons/by-course/:courseId", can(rbac.PermReadContent), adminHandler.GetLessonsByCourseId)
 
 	// Exercise routes
-	public.POST("/admin/exercise/create", adminHandler.CreateNewExercise)
-	public.PUT("/admin/exercise/:exerciseId", adminHandler.UpdateExerciseById)
-	public.DELETE("/admin/exercise/:exerciseId", adminHandler.DeleteExercise)
-	public.GET("/admin/exercise/:exerciseId", adminHandler.GetExerciseById)
-	public.GET("/admin/exercise/exercises/all", adminHandler.GetAllExercises)
-	public.GET("/admin/exercise/exercises/by-lesson/:lessonId", adminHandler.GetExercisesByLessonId)
+	admin.POST("/exercise/create/:lessonId", can(rbac.PermWriteContent), adminHandler.CreateNewExercise)
+	admin.PUT("/exercise/:exerciseId", can(rbac.PermWriteContent), adminHandler.UpdateExerciseById)
+	admin.DELETE("/exercise/:exerciseId", can(rbac.PermDeleteLessons), adminHandler.DeleteExercise)
+	admin.GET("/exercise/:exerciseId", can(rbac.PermReadContent), adminHandler.GetExerciseById)
+	admin.GET("/exercise/exercises/all", can(rbac.PermReadContent), adminHandler.GetAllExercises)
+	admin.GET("/exercise/exercises/by-lesson/:lessonId", can(rbac.PermReadContent), adminHandler.GetExercisesByLessonId)
+	admin.GET("/exercise/:exerciseId/vocabulary", can(rbac.PermReadContent), adminHandler.GetExerciseVocabulary)
+	admin.PUT("/exercise/:exerciseId/vocabulary", can(rbac.PermWriteContent), adminHandler.SetExerciseVocabulary)
+
+	// Vocabulary routes
+	admin.POST("/vocabulary/create/:languageId", can(rbac.PermWriteContent), adminHandler.CreateVocabulary)
+	admin.PUT("/vocabulary/:vocabularyId", can(rbac.PermWriteContent), adminHandler.UpdateVocabularyById)
+	admin.DELETE("/vocabulary/:vocabularyId", can(rbac.PermDeleteLessons), adminHandler.DeleteVocabulary)
+	admin.GET("/vocabulary/:vocabularyId", can(rbac.PermReadContent), adminHandler.GetVocabularyById)
+	admin.GET("/vocabulary/by-language/:languageId", can(rbac.PermReadContent), adminHandler.GetVocabularyByLanguage)
 
 	// User routes
-	public.GET("/users/me", dummy)
-	public.PUT("/users/me", dummy)
-	public.GET("/users/id", dummy)
+	learner.GET("/me", learnerHandler.GetMyProfile)
+	learner.PUT("/me", learnerHandler.UpdateMyProfile)
+	learner.GET("/me/courses", enrollmentHandler.ListMyCourses)
+	learner.GET("/me/streak", streakHandler.GetMyStreak)
+	learner.GET("/me/streak/history", streakHandler.GetStreakHistory)
+	learner.PUT("/me/timezone", streakHandler.UpdateTimezone)
+	learner.GET("/me/xp", xpHandler.GetXPHistory)
+	learner.GET("/me/vocabulary", vocabularyHandler.GetLearnedWords)
+	learner.POST("/me/friends/:friendId", leaderboardHandler.AddFriend)
+	learner.DELETE("/me/friends/:friendId", leaderboardHandler.RemoveFriend)
+	learner.GET("/:id", learnerHandler.GetLearnerProfile)
+
+	// Enrollment routes
+	languages.GET("", enrollmentHandler.ListLanguages)
+	languages.GET("/:languageId/courses", enrollmentHandler.ListLanguageCourses)
+	courses.POST("/:courseId/enroll", enrollmentHandler.EnrollInCourse)
+	courses.DELETE("/:courseId/enroll", enrollmentHandler.UnenrollFromCourse)
+	courses.GET("/:courseId/map", courseMapHandler.GetCourseMap)
+
+	// Practice routes
+	lessons.GET("/:lessonId/exercises", exerciseHandler.ListLessonExercises)
+	exercises.POST("/:exerciseId/submit", exerciseHandler.SubmitAnswer)
+	review.GET("/due", reviewHandler.GetDueReviews)
+
+	// Leaderboard routes
+	leaderboard.GET("", leaderboardHandler.GetGlobalLeaderboard)
+	leaderboard.GET("/languages/:languageId", leaderboardHandler.GetLanguageLeaderboard)
+	leaderboard.GET("/friends", leaderboardHandler.GetFriendsLeaderboard)
+	leagues.GET("/me", leagueHandler.GetMyLeague)
 
 	server.router = router
 	return server
@@ -0,0 +1,123 @@
+// Command bootstrap creates the very first super admin. Once a super admin
+// exists it refuses to run, and new admins have to be invited instead.
+//
+// The password is read from BOOTSTRAP_ADMIN_PASSWORD, or from stdin if unset.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/auth/rbac"
+	"lingo/utils"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func main() {
+	email := flag.String("email", "", "email address of the super admin")
+	firstName := flag.String("first-name", "", "first name of the super admin")
+	lastName := flag.String("last-name", "", "last name of the super admin")
+	flag.Parse()
+
+	if *email == "" || *firstName == "" || *lastName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		log.Fatalf("Couldn't read password due to %s", err.Error())
+	}
+
+	// Load config
+	config, err := utils.LoadConfig("../../")
+	if err != nil {
+		log.Fatalf("Couldn't fetch config due to %s", err.Error())
+	}
+
+	policy, err := utils.NewPasswordPolicy(config.PasswordMinLength, config.BreachedPasswordsFile)
+	if err != nil {
+		log.Fatalf("Couldn't load password policy due to %s", err.Error())
+	}
+	if err := policy.Validate(password); err != nil {
+		log.Fatalf("Invalid password: %s", err.Error())
+	}
+
+	// Connect to database
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, config.DBSource)
+	if err != nil {
+		log.Fatalf("Couldn't connect to database due to %s", err.Error())
+	}
+	defer pool.Close()
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		log.Fatalf("Couldn't hash password due to %s", err.Error())
+	}
+
+	store := db.NewSQLStore(pool)
+	err = store.ExecTx(ctx, func(q db.Querier) error {
+		count, err := q.CountSuperAdmins(ctx)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("a super admin already exists, invite new admins instead")
+		}
+
+		_, err = q.GetAdminByEmail(ctx, *email)
+		if err == nil {
+			return fmt.Errorf("an admin with email %s already exists", *email)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		admin, err := q.CreateAdmin(ctx, db.CreateAdminParams{
+			FirstName: *firstName,
+			LastName:  *lastName,
+			Email:     *email,
+			Password:  hashedPassword,
+			Role:      string(rbac.RoleSuperAdmin),
+		})
+		if err != nil {
+			return err
+		}
+
+		// The operator running this has access to the server, so trust the email they gave
+		_, err = q.MarkAdminEmailVerified(ctx, db.MarkAdminEmailVerifiedParams{
+			EmailVerifiedAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+			AdminID:         admin.AdminID,
+		})
+		return err
+	})
+	if err != nil {
+		log.Fatalf("Couldn't create super admin due to %s", err.Error())
+	}
+
+	log.Println("Created super admin", *email)
+}
+
+func readPassword() (string, error) {
+	if password := os.Getenv("BOOTSTRAP_ADMIN_PASSWORD"); password != "" {
+		return password, nil
+	}
+
+	fmt.Print("Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,16 @@
+CREATE TABLE sessions (
+    session_id UUID PRIMARY KEY,
+    family_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    owner_role VARCHAR(20) NOT NULL CHECK (owner_role IN ('admin', 'learner')),
+    refresh_token_hash VARCHAR(64) NOT NULL,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    client_ip VARCHAR(45) NOT NULL DEFAULT '',
+    is_revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    rotated_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sessions_owner ON sessions (owner_id, owner_role);
+CREATE INDEX idx_sessions_family ON sessions (family_id);
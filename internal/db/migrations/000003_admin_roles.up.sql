@@ -0,0 +1,12 @@
+ALTER TABLE admins
+ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'reviewer' CHECK (
+    role IN (
+        'super_admin',
+        'content_editor',
+        'reviewer'
+    )
+);
+
+-- Admins created before roles existed could do everything, so keep it that way.
+-- New admins start with the least privileged role.
+UPDATE admins SET role = 'super_admin';
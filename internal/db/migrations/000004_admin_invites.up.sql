@@ -0,0 +1,18 @@
+CREATE TABLE admin_invites (
+    invite_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'reviewer' CHECK (
+        role IN (
+            'super_admin',
+            'content_editor',
+            'reviewer'
+        )
+    ),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by UUID REFERENCES admins(admin_id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_invites_email ON admin_invites (email);
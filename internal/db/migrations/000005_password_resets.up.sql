@@ -0,0 +1,11 @@
+CREATE TABLE password_resets (
+    reset_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL,
+    owner_role VARCHAR(20) NOT NULL CHECK (owner_role IN ('admin', 'learner')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_password_resets_owner ON password_resets (owner_id, owner_role);
@@ -0,0 +1,7 @@
+ALTER TABLE admins
+DROP COLUMN IF EXISTS verification_sent_at,
+DROP COLUMN IF EXISTS email_verified_at;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS verification_sent_at,
+DROP COLUMN IF EXISTS email_verified_at;
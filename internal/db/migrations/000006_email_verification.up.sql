@@ -0,0 +1,7 @@
+ALTER TABLE users
+ADD COLUMN email_verified_at TIMESTAMP,
+ADD COLUMN verification_sent_at TIMESTAMP;
+
+ALTER TABLE admins
+ADD COLUMN email_verified_at TIMESTAMP,
+ADD COLUMN verification_sent_at TIMESTAMP;
@@ -0,0 +1,8 @@
+CREATE TABLE login_throttles (
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('account', 'ip')),
+    throttle_key VARCHAR(150) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    PRIMARY KEY (scope, throttle_key)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS admin_recovery_codes CASCADE;
+
+ALTER TABLE admins
+DROP COLUMN IF EXISTS totp_last_step,
+DROP COLUMN IF EXISTS totp_enabled_at,
+DROP COLUMN IF EXISTS totp_secret;
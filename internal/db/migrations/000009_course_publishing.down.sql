@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_courses_published_language;
+
+ALTER TABLE courses DROP COLUMN IF EXISTS is_published;
@@ -0,0 +1,9 @@
+ALTER TABLE courses
+ADD COLUMN is_published BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Courses created before publishing existed were already visible to learners
+UPDATE courses SET is_published = TRUE;
+
+CREATE INDEX idx_courses_published_language ON courses (language_id)
+WHERE
+    is_published;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS streak_days CASCADE;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS last_streak_date,
+DROP COLUMN IF EXISTS streak_freezes,
+DROP COLUMN IF EXISTS longest_streak,
+DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,13 @@
+ALTER TABLE users
+ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Africa/Lagos',
+ADD COLUMN longest_streak INT NOT NULL DEFAULT 0,
+ADD COLUMN streak_freezes INT NOT NULL DEFAULT 0,
+ADD COLUMN last_streak_date DATE;
+
+CREATE TABLE streak_days (
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    streak_date DATE NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('active', 'frozen')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, streak_date)
+);
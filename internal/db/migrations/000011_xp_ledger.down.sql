@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS xp_ledger CASCADE;
+
+DROP FUNCTION IF EXISTS reject_xp_ledger_update();
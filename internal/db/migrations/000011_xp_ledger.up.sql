@@ -0,0 +1,36 @@
+CREATE TABLE xp_ledger (
+    entry_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    source VARCHAR(30) NOT NULL CHECK (
+        source IN (
+            'lesson_completion',
+            'perfect_score',
+            'streak_bonus'
+        )
+    ),
+    amount INT NOT NULL CHECK (amount > 0),
+    -- Identifies what the award was for, so replaying it can't award it twice
+    idempotency_key VARCHAR(200) NOT NULL,
+    lesson_id UUID REFERENCES lessons (lesson_id) ON DELETE SET NULL,
+    awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_xp_award UNIQUE (user_id, idempotency_key)
+);
+
+CREATE INDEX idx_xp_ledger_user_awarded ON xp_ledger (user_id, awarded_at);
+
+-- Awards are never edited, only appended. Deleting a lesson sets lesson_id to
+-- NULL on its awards, which Postgres carries out as an UPDATE, so that is the
+-- only change allowed.
+CREATE FUNCTION reject_xp_ledger_update() RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.lesson_id IS NOT NULL
+        AND NEW.lesson_id IS NULL
+        AND to_jsonb(NEW) - 'lesson_id' = to_jsonb(OLD) - 'lesson_id' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'xp_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER xp_ledger_append_only BEFORE UPDATE ON xp_ledger
+FOR EACH ROW EXECUTE FUNCTION reject_xp_ledger_update();
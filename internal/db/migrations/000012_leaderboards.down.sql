@@ -0,0 +1,11 @@
+DROP MATERIALIZED VIEW IF EXISTS language_leaderboard;
+
+DROP MATERIALIZED VIEW IF EXISTS global_leaderboard;
+
+DROP TABLE IF EXISTS league_members CASCADE;
+
+DROP TABLE IF EXISTS leagues CASCADE;
+
+DROP TABLE IF EXISTS friendships CASCADE;
+
+ALTER TABLE users DROP COLUMN IF EXISTS league_tier;
@@ -0,0 +1,80 @@
+ALTER TABLE users ADD COLUMN league_tier INT NOT NULL DEFAULT 0;
+
+CREATE TABLE friendships (
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    friend_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, friend_id),
+    CONSTRAINT no_self_friendship CHECK (user_id <> friend_id)
+);
+
+CREATE TABLE leagues (
+    league_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tier INT NOT NULL CHECK (tier >= 0),
+    -- Monday, in UTC, of the week the league runs
+    week_start DATE NOT NULL,
+    member_count INT NOT NULL DEFAULT 0,
+    settled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_leagues_week_tier ON leagues (week_start, tier);
+
+CREATE TABLE league_members (
+    league_id UUID NOT NULL REFERENCES leagues (league_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    xp INT NOT NULL DEFAULT 0,
+    final_rank INT,
+    outcome VARCHAR(10) CHECK (
+        outcome IN ('promoted', 'demoted', 'stayed')
+    ),
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (league_id, user_id),
+    CONSTRAINT one_league_per_week UNIQUE (user_id, week_start)
+);
+
+CREATE INDEX idx_league_members_standings ON league_members (league_id, xp DESC);
+
+-- Leaderboards are refreshed periodically rather than ranked on every request
+CREATE MATERIALIZED VIEW global_leaderboard AS
+SELECT
+    user_id,
+    username,
+    profile_image_url,
+    COALESCE(xp_points, 0)::INT AS xp,
+    RANK() OVER (
+        ORDER BY COALESCE(xp_points, 0) DESC
+    ) AS rank
+FROM users;
+
+CREATE UNIQUE INDEX idx_global_leaderboard_user ON global_leaderboard (user_id);
+
+CREATE INDEX idx_global_leaderboard_rank ON global_leaderboard (rank);
+
+-- XP earned from each language's lessons
+CREATE MATERIALIZED VIEW language_leaderboard AS
+SELECT
+    c.language_id,
+    x.user_id,
+    u.username,
+    u.profile_image_url,
+    SUM(x.amount)::BIGINT AS xp,
+    RANK() OVER (
+        PARTITION BY c.language_id
+        ORDER BY SUM(x.amount) DESC
+    ) AS rank
+FROM
+    xp_ledger x
+    JOIN lessons l ON l.lesson_id = x.lesson_id
+    JOIN courses c ON c.course_id = l.course_id
+    JOIN users u ON u.user_id = x.user_id
+GROUP BY
+    c.language_id,
+    x.user_id,
+    u.username,
+    u.profile_image_url;
+
+CREATE UNIQUE INDEX idx_language_leaderboard_user ON language_leaderboard (language_id, user_id);
+
+CREATE INDEX idx_language_leaderboard_rank ON language_leaderboard (language_id, rank);
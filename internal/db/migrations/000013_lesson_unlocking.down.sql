@@ -0,0 +1,3 @@
+ALTER TABLE lessons
+DROP COLUMN IF EXISTS is_checkpoint,
+DROP COLUMN IF EXISTS unlock_score;
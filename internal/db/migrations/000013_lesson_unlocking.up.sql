@@ -0,0 +1,11 @@
+-- Lessons now unlock per learner. is_unlocked only overrides the rules, opening
+-- a lesson to every learner.
+ALTER TABLE lessons
+ADD COLUMN is_checkpoint BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN unlock_score INT CHECK (
+    unlock_score IS NULL
+    OR (
+        unlock_score >= 0
+        AND unlock_score <= 100
+    )
+);
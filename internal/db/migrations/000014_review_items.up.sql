@@ -0,0 +1,35 @@
+CREATE TABLE review_items (
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES exercises (exercise_id) ON DELETE CASCADE,
+    ease_factor DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+    interval_days INT NOT NULL DEFAULT 0,
+    repetitions INT NOT NULL DEFAULT 0,
+    due_at TIMESTAMP NOT NULL,
+    last_reviewed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, exercise_id)
+);
+
+CREATE INDEX idx_review_items_due ON review_items (user_id, due_at);
+
+-- Exercises completed before reviews existed are due a day after their completion
+INSERT INTO
+    review_items (
+        user_id,
+        exercise_id,
+        ease_factor,
+        interval_days,
+        repetitions,
+        due_at,
+        last_reviewed_at
+    )
+SELECT
+    user_id,
+    exercise_id,
+    2.5,
+    1,
+    1,
+    COALESCE(completed_at, CURRENT_TIMESTAMP) + INTERVAL '1 day',
+    COALESCE(completed_at, CURRENT_TIMESTAMP)
+FROM user_progress
+WHERE
+    is_completed = TRUE;
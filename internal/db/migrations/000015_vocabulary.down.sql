@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS exercise_vocabulary CASCADE;
+
+DROP TABLE IF EXISTS vocabulary CASCADE;
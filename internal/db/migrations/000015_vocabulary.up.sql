@@ -0,0 +1,33 @@
+CREATE TABLE vocabulary (
+    vocabulary_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    language_id UUID NOT NULL REFERENCES languages (language_id) ON DELETE CASCADE,
+    word VARCHAR(100) NOT NULL,
+    translation VARCHAR(255) NOT NULL,
+    part_of_speech VARCHAR(20) CHECK (
+        part_of_speech IN (
+            'noun',
+            'pronoun',
+            'verb',
+            'adjective',
+            'adverb',
+            'preposition',
+            'conjunction',
+            'interjection',
+            'phrase'
+        )
+    ),
+    -- The word with its tone marks, such as ọmọ for omo
+    tone_marked VARCHAR(100),
+    audio_url VARCHAR(255),
+    example_sentence TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_vocabulary_meaning UNIQUE (language_id, word, translation)
+);
+
+CREATE TABLE exercise_vocabulary (
+    exercise_id UUID NOT NULL REFERENCES exercises (exercise_id) ON DELETE CASCADE,
+    vocabulary_id UUID NOT NULL REFERENCES vocabulary (vocabulary_id) ON DELETE CASCADE,
+    PRIMARY KEY (exercise_id, vocabulary_id)
+);
+
+CREATE INDEX idx_exercise_vocabulary_vocabulary ON exercise_vocabulary (vocabulary_id);
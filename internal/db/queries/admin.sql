@@ -1,5 +1,9 @@
 -- name: GetAdminForLogin :one
-SELECT admin_id, email, password
+SELECT
+    admin_id,
+    email,
+    password,
+    email_verified_at
 FROM admins
 WHERE
     email = $1
@@ -12,9 +16,10 @@ INSERT INTO
         last_name,
         email,
         password,
-        profile_image_url
+        profile_image_url,
+        role
     )
-VALUES ($1, $2, $3, $4, $5) RETURNING *;
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
 
 -- name: GetAdminById :one
 SELECT
@@ -23,12 +28,47 @@ SELECT
     last_name,
     email,
     profile_image_url,
+    role,
     joined_at
 FROM admins
 WHERE
     admin_id = $1
 LIMIT 1;
 
+-- name: GetAdminCredentials :one
+SELECT
+    admin_id,
+    first_name,
+    email,
+    password
+FROM admins
+WHERE
+    admin_id = $1
+LIMIT 1;
+
+-- Returns 0 rows affected if the email was already verified
+-- name: MarkAdminEmailVerified :execrows
+UPDATE admins
+SET
+    email_verified_at = $1
+WHERE
+    admin_id = $2
+    AND email_verified_at IS NULL;
+
+-- Starts the resend cooldown for a verification email.
+-- Returns 0 rows affected if the admin is already verified or was emailed too recently.
+-- name: ReserveAdminVerificationEmail :execrows
+UPDATE admins
+SET
+    verification_sent_at = sqlc.arg(sent_at)
+WHERE
+    admin_id = sqlc.arg(admin_id)
+    AND email_verified_at IS NULL
+    AND (
+        verification_sent_at IS NULL
+        OR verification_sent_at < sqlc.arg(resend_before)
+    );
+
 -- name: GetAdminByEmail :one
 SELECT
     admin_id,
@@ -58,6 +98,30 @@ WHERE
     profile_image_url,
     joined_at;
 
+-- name: GetAdminRole :one
+SELECT role FROM admins WHERE admin_id = $1 LIMIT 1;
+
+-- name: CountSuperAdmins :one
+SELECT COUNT(*) FROM admins WHERE role = 'super_admin';
+
+-- name: ListAdmins :many
+SELECT
+    admin_id,
+    first_name,
+    last_name,
+    email,
+    role,
+    joined_at
+FROM admins
+ORDER BY joined_at ASC
+LIMIT $1
+OFFSET
+    $2;
+
+-- Update admin role
+-- name: UpdateAdminRole :exec
+UPDATE admins SET role = $1 WHERE admin_id = $2;
+
 -- Delete admin by ID
 -- name: DeleteAdmin :exec
 DELETE FROM admins WHERE admin_id = $1;
@@ -90,9 +154,11 @@ INSERT INTO
         course_id,
         lesson_order,
         xp_reward,
-        is_unlocked
+        is_unlocked,
+        is_checkpoint,
+        unlock_score
     )
-VALUES ($1, $2, $3, $4, $5) RETURNING *;
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 
 -- name: CreateExercise :one
 INSERT INTO
@@ -213,7 +279,9 @@ SELECT
     course_id,
     lesson_order,
     xp_reward,
-    is_unlocked
+    is_unlocked,
+    is_checkpoint,
+    unlock_score
 FROM lessons
 WHERE
     lesson_id = $1
@@ -226,7 +294,9 @@ SELECT
     course_id,
     lesson_order,
     xp_reward,
-    is_unlocked
+    is_unlocked,
+    is_checkpoint,
+    unlock_score
 FROM lessons
 WHERE
     course_id = $1
@@ -270,7 +340,15 @@ SET
     first_name = $1,
     last_name = $2,
     email = $3,
-    profile_image_url = $4
+    profile_image_url = $4,
+    email_verified_at = CASE
+        WHEN email = $3 THEN email_verified_at
+        ELSE NULL
+    END,
+    verification_sent_at = CASE
+        WHEN email = $3 THEN verification_sent_at
+        ELSE NULL
+    END
 WHERE
     admin_id = $5;
 
@@ -307,9 +385,11 @@ SET
     lesson_title = $1,
     lesson_order = $2,
     xp_reward = $3,
-    is_unlocked = $4
+    is_unlocked = $4,
+    is_checkpoint = $5,
+    unlock_score = $6
 WHERE
-    lesson_id = $5;
+    lesson_id = $7;
 
 -- Update exercise details
 -- name: UpdateExerciseDetails :exec
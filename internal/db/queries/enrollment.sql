@@ -0,0 +1,31 @@
+-- name: GetPublishedCourse :one
+SELECT *
+FROM courses
+WHERE
+    course_id = $1
+    AND is_published = TRUE
+LIMIT 1;
+
+-- name: ListPublishedCoursesByLanguage :many
+SELECT *
+FROM courses
+WHERE
+    language_id = $1
+    AND is_published = TRUE
+ORDER BY course_name ASC;
+
+-- Returns 0 rows affected if the course doesn't exist
+-- name: SetCoursePublished :execrows
+UPDATE courses SET is_published = $1 WHERE course_id = $2;
+
+-- name: GetUserCourse :one
+SELECT *
+FROM user_courses
+WHERE
+    user_id = $1
+    AND course_id = $2
+LIMIT 1;
+
+-- Returns 0 rows affected if the user wasn't enrolled
+-- name: DeleteUserCourse :execrows
+DELETE FROM user_courses WHERE user_id = $1 AND course_id = $2;
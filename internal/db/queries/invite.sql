@@ -0,0 +1,27 @@
+-- name: CreateAdminInvite :one
+INSERT INTO
+    admin_invites (
+        email,
+        role,
+        token_hash,
+        invited_by,
+        expires_at
+    )
+VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- Locks the invite so two signups can't redeem it at the same time
+-- name: GetAdminInviteForUpdate :one
+SELECT *
+FROM admin_invites
+WHERE
+    token_hash = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: MarkAdminInviteUsed :execrows
+UPDATE admin_invites
+SET
+    used_at = CURRENT_TIMESTAMP
+WHERE
+    invite_id = $1
+    AND used_at IS NULL;
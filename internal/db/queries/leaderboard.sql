@@ -0,0 +1,67 @@
+-- name: ListGlobalLeaderboard :many
+SELECT *
+FROM global_leaderboard
+ORDER BY rank, username
+LIMIT $1
+OFFSET $2;
+
+-- name: GetGlobalLeaderboardEntry :one
+SELECT * FROM global_leaderboard WHERE user_id = $1 LIMIT 1;
+
+-- name: ListLanguageLeaderboard :many
+SELECT *
+FROM language_leaderboard
+WHERE
+    language_id = $1
+ORDER BY rank, username
+LIMIT $2
+OFFSET $3;
+
+-- name: GetLanguageLeaderboardEntry :one
+SELECT *
+FROM language_leaderboard
+WHERE
+    language_id = $1
+    AND user_id = $2
+LIMIT 1;
+
+-- Rebuilds the global leaderboard without blocking reads
+-- name: RefreshGlobalLeaderboard :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY global_leaderboard;
+
+-- Rebuilds the language leaderboards without blocking reads
+-- name: RefreshLanguageLeaderboard :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY language_leaderboard;
+
+-- Ranks the learner and their friends live, as the group is small
+-- name: ListFriendsLeaderboard :many
+SELECT
+    u.user_id,
+    u.username,
+    u.profile_image_url,
+    COALESCE(u.xp_points, 0)::INT AS xp,
+    RANK() OVER (
+        ORDER BY COALESCE(u.xp_points, 0) DESC
+    ) AS rank
+FROM users u
+WHERE
+    u.user_id = sqlc.arg(user_id)
+    OR u.user_id IN (
+        SELECT f.friend_id
+        FROM friendships f
+        WHERE
+            f.user_id = sqlc.arg(user_id)
+    )
+ORDER BY rank, u.username;
+
+-- name: CreateFriendship :exec
+INSERT INTO
+    friendships (user_id, friend_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, friend_id) DO NOTHING;
+
+-- name: DeleteFriendship :execrows
+DELETE FROM friendships WHERE user_id = $1 AND friend_id = $2;
+
+-- name: CountFriendships :one
+SELECT COUNT(*) FROM friendships WHERE user_id = $1;
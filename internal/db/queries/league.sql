@@ -0,0 +1,114 @@
+-- name: GetUserLeagueTier :one
+SELECT league_tier FROM users WHERE user_id = $1 LIMIT 1;
+
+-- name: SetUserLeagueTier :exec
+UPDATE users SET league_tier = $1 WHERE user_id = $2;
+
+-- Adds XP to the learner's league for the week, if they have joined one
+-- name: AddLeagueXP :execrows
+UPDATE league_members
+SET
+    xp = xp + sqlc.arg(amount)
+WHERE
+    user_id = sqlc.arg(user_id)
+    AND week_start = sqlc.arg(week_start);
+
+-- Finds the oldest league of a tier with room left, skipping leagues other learners are joining
+-- name: GetOpenLeagueForUpdate :one
+SELECT *
+FROM leagues
+WHERE
+    week_start = sqlc.arg(week_start)
+    AND tier = sqlc.arg(tier)
+    AND member_count < sqlc.arg(max_members)
+ORDER BY created_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: CreateLeague :one
+INSERT INTO leagues (tier, week_start) VALUES ($1, $2) RETURNING *;
+
+-- name: IncrementLeagueMembers :exec
+UPDATE leagues
+SET
+    member_count = member_count + 1
+WHERE
+    league_id = $1;
+
+-- name: CreateLeagueMember :one
+INSERT INTO
+    league_members (
+        league_id,
+        user_id,
+        week_start,
+        xp,
+        joined_at
+    )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetUserLeague :one
+SELECT l.*
+FROM leagues l
+    JOIN league_members m ON m.league_id = l.league_id
+WHERE
+    m.user_id = $1
+    AND m.week_start = $2
+LIMIT 1;
+
+-- name: GetUserLeagueResult :one
+SELECT l.tier, m.week_start, m.final_rank, m.outcome
+FROM league_members m
+    JOIN leagues l ON l.league_id = m.league_id
+WHERE
+    m.user_id = $1
+    AND m.week_start = $2
+    AND l.settled_at IS NOT NULL
+LIMIT 1;
+
+-- Leagues from before the given week that the learner was in and that haven't been settled yet, oldest first
+-- name: ListUnsettledUserLeagues :many
+SELECT l.league_id
+FROM leagues l
+    JOIN league_members m ON m.league_id = l.league_id
+WHERE
+    m.user_id = $1
+    AND l.week_start < $2
+    AND l.settled_at IS NULL
+ORDER BY l.week_start;
+
+-- Locks a league for settling, returning no rows if it's already settled
+-- name: GetUnsettledLeagueForUpdate :one
+SELECT *
+FROM leagues
+WHERE
+    league_id = $1
+    AND settled_at IS NULL
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListLeagueStandings :many
+SELECT
+    m.user_id,
+    u.username,
+    u.profile_image_url,
+    m.xp,
+    m.final_rank,
+    m.outcome
+FROM league_members m
+    JOIN users u ON u.user_id = m.user_id
+WHERE
+    m.league_id = $1
+ORDER BY m.xp DESC, m.joined_at;
+
+-- name: SettleLeagueMember :exec
+UPDATE league_members
+SET
+    final_rank = $1,
+    outcome = $2
+WHERE
+    league_id = $3
+    AND user_id = $4;
+
+-- name: SettleLeague :exec
+UPDATE leagues SET settled_at = $1 WHERE league_id = $2;
@@ -0,0 +1,134 @@
+-- name: CreateUser :one
+INSERT INTO
+    users (username, email, password)
+VALUES ($1, $2, $3) RETURNING user_id,
+    username,
+    email,
+    profile_image_url,
+    streak_count,
+    xp_points,
+    last_active_date,
+    joined_at;
+
+-- name: GetUserForLogin :one
+SELECT
+    user_id,
+    email,
+    password,
+    email_verified_at
+FROM users
+WHERE
+    email = $1
+LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT
+    user_id,
+    username,
+    email,
+    profile_image_url,
+    joined_at
+FROM users
+WHERE
+    email = $1
+LIMIT 1;
+
+-- name: GetUserByUsername :one
+SELECT
+    user_id,
+    username,
+    email,
+    profile_image_url,
+    joined_at
+FROM users
+WHERE
+    username = $1
+LIMIT 1;
+
+-- name: GetUserById :one
+SELECT
+    user_id,
+    username,
+    email,
+    profile_image_url,
+    streak_count,
+    xp_points,
+    last_active_date,
+    joined_at,
+    email_verified_at
+FROM users
+WHERE
+    user_id = $1
+LIMIT 1;
+
+-- Courses a user is enrolled in, most recent first
+-- name: ListUserCourses :many
+SELECT
+    uc.course_id,
+    c.course_name,
+    c.difficulty_level,
+    l.language_name,
+    l.language_code,
+    l.flag_emoji,
+    uc.enrollment_date,
+    uc.completion_percentage
+FROM
+    user_courses uc
+    JOIN courses c ON uc.course_id = c.course_id
+    JOIN languages l ON c.language_id = l.language_id
+WHERE
+    uc.user_id = $1
+ORDER BY uc.enrollment_date DESC;
+
+-- Changing the email clears its verification
+-- name: UpdateUserProfile :one
+UPDATE users
+SET
+    username = $1,
+    email = $2,
+    profile_image_url = $3,
+    email_verified_at = CASE
+        WHEN email = $2 THEN email_verified_at
+        ELSE NULL
+    END,
+    verification_sent_at = CASE
+        WHEN email = $2 THEN verification_sent_at
+        ELSE NULL
+    END
+WHERE
+    user_id = $4 RETURNING user_id,
+    username,
+    email,
+    profile_image_url,
+    streak_count,
+    xp_points,
+    last_active_date,
+    joined_at,
+    email_verified_at;
+
+-- Update user password
+-- name: UpdateUserPassword :exec
+UPDATE users SET password = $1 WHERE user_id = $2;
+
+-- Returns 0 rows affected if the email was already verified
+-- name: MarkUserEmailVerified :execrows
+UPDATE users
+SET
+    email_verified_at = $1
+WHERE
+    user_id = $2
+    AND email_verified_at IS NULL;
+
+-- Starts the resend cooldown for a verification email.
+-- Returns 0 rows affected if the user is already verified or was emailed too recently.
+-- name: ReserveUserVerificationEmail :execrows
+UPDATE users
+SET
+    verification_sent_at = sqlc.arg(sent_at)
+WHERE
+    user_id = sqlc.arg(user_id)
+    AND email_verified_at IS NULL
+    AND (
+        verification_sent_at IS NULL
+        OR verification_sent_at < sqlc.arg(resend_before)
+    );
@@ -0,0 +1,43 @@
+-- name: GetLoginThrottle :one
+SELECT *
+FROM login_throttles
+WHERE
+    scope = $1
+    AND throttle_key = $2
+LIMIT 1;
+
+-- Counts a failed login, starting over if the previous failure is older than reset_before
+-- name: RecordLoginFailure :one
+INSERT INTO
+    login_throttles (
+        scope,
+        throttle_key,
+        failed_count,
+        last_failed_at
+    )
+VALUES (
+        sqlc.arg(scope),
+        sqlc.arg(throttle_key),
+        1,
+        sqlc.arg(failed_at)
+    )
+ON CONFLICT (scope, throttle_key) DO
+UPDATE
+SET
+    failed_count = CASE
+        WHEN login_throttles.last_failed_at < sqlc.arg(reset_before) THEN 1
+        ELSE login_throttles.failed_count + 1
+    END,
+    last_failed_at = EXCLUDED.last_failed_at RETURNING *;
+
+-- name: LockLogin :exec
+UPDATE login_throttles
+SET
+    locked_until = $1
+WHERE
+    scope = $2
+    AND throttle_key = $3;
+
+-- Clears the failed logins and any lock of an account or IP
+-- name: DeleteLoginThrottle :execrows
+DELETE FROM login_throttles WHERE scope = $1 AND throttle_key = $2;
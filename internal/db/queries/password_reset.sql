@@ -0,0 +1,28 @@
+-- name: CreatePasswordReset :one
+INSERT INTO
+    password_resets (
+        owner_id,
+        owner_role,
+        token_hash,
+        expires_at
+    )
+VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- Locks the reset so the same code can't be redeemed twice concurrently
+-- name: GetPasswordResetForUpdate :one
+SELECT *
+FROM password_resets
+WHERE
+    token_hash = $1
+LIMIT 1
+FOR UPDATE;
+
+-- Marks every outstanding reset of an admin or learner as used
+-- name: InvalidatePasswordResets :exec
+UPDATE password_resets
+SET
+    used_at = CURRENT_TIMESTAMP
+WHERE
+    owner_id = $1
+    AND owner_role = $2
+    AND used_at IS NULL;
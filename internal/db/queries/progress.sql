@@ -0,0 +1,123 @@
+-- name: GetExerciseForGrading :one
+SELECT
+    e.exercise_id,
+    e.lesson_id,
+    l.course_id,
+    e.exercise_type,
+    e.correct_answer,
+    e.options
+FROM exercises e
+    JOIN lessons l ON e.lesson_id = l.lesson_id
+WHERE
+    e.exercise_id = $1
+LIMIT 1;
+
+-- Exercises as shown to learners, without the correct answer
+-- name: ListLessonExercises :many
+SELECT
+    exercise_id,
+    lesson_id,
+    exercise_type,
+    question_text,
+    options,
+    audio_url
+FROM exercises
+WHERE
+    lesson_id = $1
+ORDER BY exercise_id ASC;
+
+-- Keeps the best score and the first completion across attempts
+-- name: RecordExerciseAttempt :one
+INSERT INTO
+    user_progress (
+        user_id,
+        lesson_id,
+        exercise_id,
+        is_completed,
+        score,
+        completed_at
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, exercise_id) DO UPDATE
+SET
+    score = GREATEST(user_progress.score, EXCLUDED.score),
+    is_completed = COALESCE(user_progress.is_completed, FALSE) OR EXCLUDED.is_completed,
+    completed_at = COALESCE(user_progress.completed_at, EXCLUDED.completed_at)
+RETURNING *;
+
+-- Recomputes a learner's completion of a course from the exercises they have completed
+-- name: RefreshCourseCompletion :one
+UPDATE user_courses uc
+SET
+    completion_percentage = (
+        SELECT COALESCE(
+                ROUND(
+                    (
+                        100.0 * COUNT(up.progress_id) / NULLIF(COUNT(e.exercise_id), 0)
+                    )::NUMERIC, 2
+                ), 0
+            )::FLOAT
+        FROM
+            lessons l
+            JOIN exercises e ON e.lesson_id = l.lesson_id
+            LEFT JOIN user_progress up ON up.exercise_id = e.exercise_id
+            AND up.user_id = uc.user_id
+            AND up.is_completed = TRUE
+        WHERE
+            l.course_id = uc.course_id
+    )
+WHERE
+    uc.user_id = $1
+    AND uc.course_id = $2
+RETURNING completion_percentage;
+
+-- Recomputes the completion of every learner enrolled in a course after its exercises change
+-- name: RefreshCourseCompletionForCourse :exec
+UPDATE user_courses uc
+SET
+    completion_percentage = (
+        SELECT COALESCE(
+                ROUND(
+                    (
+                        100.0 * COUNT(up.progress_id) / NULLIF(COUNT(e.exercise_id), 0)
+                    )::NUMERIC, 2
+                ), 0
+            )::FLOAT
+        FROM
+            lessons l
+            JOIN exercises e ON e.lesson_id = l.lesson_id
+            LEFT JOIN user_progress up ON up.exercise_id = e.exercise_id
+            AND up.user_id = uc.user_id
+            AND up.is_completed = TRUE
+        WHERE
+            l.course_id = uc.course_id
+    )
+WHERE
+    uc.course_id = $1;
+
+-- A learner's progress on every lesson of a course, in lesson order
+-- name: ListCourseLessonProgress :many
+SELECT
+    l.lesson_id,
+    l.lesson_title,
+    l.lesson_order,
+    l.xp_reward,
+    l.is_unlocked,
+    l.is_checkpoint,
+    l.unlock_score,
+    COUNT(e.exercise_id) AS total_exercises,
+    COUNT(up.progress_id) FILTER (
+        WHERE
+            up.is_completed = TRUE
+    ) AS completed_exercises,
+    COALESCE(SUM(up.score), 0)::BIGINT AS total_score
+FROM
+    lessons l
+    LEFT JOIN exercises e ON e.lesson_id = l.lesson_id
+    LEFT JOIN user_progress up ON up.exercise_id = e.exercise_id
+    AND up.user_id = $1
+WHERE
+    l.course_id = $2
+GROUP BY
+    l.lesson_id
+ORDER BY l.lesson_order;
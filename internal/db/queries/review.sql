@@ -0,0 +1,69 @@
+-- name: GetReviewItemForUpdate :one
+SELECT *
+FROM review_items
+WHERE
+    user_id = $1
+    AND exercise_id = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: UpsertReviewItem :one
+INSERT INTO
+    review_items (
+        user_id,
+        exercise_id,
+        ease_factor,
+        interval_days,
+        repetitions,
+        due_at,
+        last_reviewed_at
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, exercise_id) DO UPDATE
+SET
+    ease_factor = EXCLUDED.ease_factor,
+    interval_days = EXCLUDED.interval_days,
+    repetitions = EXCLUDED.repetitions,
+    due_at = EXCLUDED.due_at,
+    last_reviewed_at = EXCLUDED.last_reviewed_at
+RETURNING *;
+
+-- Due exercises from the learner's enrolled courses in a language, most overdue first
+-- name: ListDueReviews :many
+SELECT
+    e.exercise_id,
+    e.lesson_id,
+    l.course_id,
+    e.exercise_type,
+    e.question_text,
+    e.options,
+    e.audio_url,
+    r.due_at,
+    r.interval_days
+FROM
+    review_items r
+    JOIN exercises e ON e.exercise_id = r.exercise_id
+    JOIN lessons l ON l.lesson_id = e.lesson_id
+    JOIN courses c ON c.course_id = l.course_id
+    JOIN user_courses uc ON uc.course_id = c.course_id
+    AND uc.user_id = r.user_id
+WHERE
+    r.user_id = sqlc.arg(user_id)
+    AND c.language_id = sqlc.arg(language_id)
+    AND r.due_at <= sqlc.arg(now)
+ORDER BY r.due_at
+LIMIT sqlc.arg(max_items);
+
+-- name: CountDueReviews :one
+SELECT COUNT(*)
+FROM
+    review_items r
+    JOIN exercises e ON e.exercise_id = r.exercise_id
+    JOIN lessons l ON l.lesson_id = e.lesson_id
+    JOIN courses c ON c.course_id = l.course_id
+    JOIN user_courses uc ON uc.course_id = c.course_id
+    AND uc.user_id = r.user_id
+WHERE
+    r.user_id = sqlc.arg(user_id)
+    AND c.language_id = sqlc.arg(language_id)
+    AND r.due_at <= sqlc.arg(now);
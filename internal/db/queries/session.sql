@@ -0,0 +1,40 @@
+-- name: CreateSession :one
+INSERT INTO
+    sessions (
+        session_id,
+        family_id,
+        owner_id,
+        owner_role,
+        refresh_token_hash,
+        user_agent,
+        client_ip,
+        expires_at
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions WHERE session_id = $1 LIMIT 1;
+
+-- Marks a session as used so its refresh token can't be presented again.
+-- Returns 0 rows affected if the session was already rotated or revoked.
+-- name: MarkSessionRotated :execrows
+UPDATE sessions
+SET
+    rotated_at = CURRENT_TIMESTAMP
+WHERE
+    session_id = $1
+    AND rotated_at IS NULL
+    AND is_revoked = FALSE;
+
+-- Revoke every session descended from the same login
+-- name: RevokeSessionFamily :exec
+UPDATE sessions SET is_revoked = TRUE WHERE family_id = $1;
+
+-- Revoke every session of an admin or learner
+-- name: RevokeSessionsByOwner :exec
+UPDATE sessions
+SET
+    is_revoked = TRUE
+WHERE
+    owner_id = $1
+    AND owner_role = $2;
@@ -0,0 +1,55 @@
+-- name: GetUserStreak :one
+SELECT
+    user_id,
+    timezone,
+    streak_count,
+    longest_streak,
+    streak_freezes,
+    last_streak_date
+FROM users
+WHERE
+    user_id = $1
+LIMIT 1;
+
+-- Locks the learner's row so concurrent activities only extend the streak once
+-- name: GetUserStreakForUpdate :one
+SELECT
+    user_id,
+    timezone,
+    streak_count,
+    longest_streak,
+    streak_freezes,
+    last_streak_date
+FROM users
+WHERE
+    user_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: UpdateUserStreak :exec
+UPDATE users
+SET
+    streak_count = $1,
+    longest_streak = $2,
+    streak_freezes = $3,
+    last_streak_date = $4,
+    last_active_date = $5
+WHERE
+    user_id = $6;
+
+-- name: RecordStreakDay :exec
+INSERT INTO
+    streak_days (user_id, streak_date, status)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, streak_date) DO NOTHING;
+
+-- name: ListStreakDays :many
+SELECT streak_date, status
+FROM streak_days
+WHERE
+    user_id = $1
+    AND streak_date BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+ORDER BY streak_date ASC;
+
+-- name: SetUserTimezone :exec
+UPDATE users SET timezone = $1 WHERE user_id = $2;
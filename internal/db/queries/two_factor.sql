@@ -0,0 +1,86 @@
+-- name: GetAdminTwoFactor :one
+SELECT
+    admin_id,
+    email,
+    role,
+    totp_secret,
+    totp_enabled_at,
+    totp_last_step
+FROM admins
+WHERE
+    admin_id = $1
+LIMIT 1;
+
+-- name: IsAdminTotpEnabled :one
+SELECT (totp_enabled_at IS NOT NULL)::BOOLEAN AS totp_enabled
+FROM admins
+WHERE
+    admin_id = $1
+LIMIT 1;
+
+-- Stores a new secret awaiting confirmation.
+-- Returns 0 rows affected if two-factor authentication is already enabled.
+-- name: SetAdminTotpSecret :execrows
+UPDATE admins
+SET
+    totp_secret = $1,
+    totp_last_step = NULL
+WHERE
+    admin_id = $2
+    AND totp_enabled_at IS NULL;
+
+-- Returns 0 rows affected if there is no pending secret or it was already enabled
+-- name: EnableAdminTotp :execrows
+UPDATE admins
+SET
+    totp_enabled_at = $1,
+    totp_last_step = $2
+WHERE
+    admin_id = $3
+    AND totp_secret IS NOT NULL
+    AND totp_enabled_at IS NULL;
+
+-- Records the time step of a used code so it can't be replayed.
+-- Returns 0 rows affected if a code from this or a later step was already used.
+-- name: UseAdminTotpStep :execrows
+UPDATE admins
+SET
+    totp_last_step = $1
+WHERE
+    admin_id = $2
+    AND (
+        totp_last_step IS NULL
+        OR totp_last_step < $1
+    );
+
+-- name: DisableAdminTotp :exec
+UPDATE admins
+SET
+    totp_secret = NULL,
+    totp_enabled_at = NULL,
+    totp_last_step = NULL
+WHERE
+    admin_id = $1;
+
+-- name: CreateAdminRecoveryCode :exec
+INSERT INTO admin_recovery_codes (admin_id, code_hash) VALUES ($1, $2);
+
+-- name: CountAdminRecoveryCodes :one
+SELECT COUNT(*)
+FROM admin_recovery_codes
+WHERE
+    admin_id = $1
+    AND used_at IS NULL;
+
+-- name: DeleteAdminRecoveryCodes :exec
+DELETE FROM admin_recovery_codes WHERE admin_id = $1;
+
+-- Returns 0 rows affected if the code doesn't exist or was already used
+-- name: UseAdminRecoveryCode :execrows
+UPDATE admin_recovery_codes
+SET
+    used_at = $1
+WHERE
+    admin_id = $2
+    AND code_hash = $3
+    AND used_at IS NULL;
@@ -0,0 +1,93 @@
+-- name: CreateVocabulary :one
+INSERT INTO
+    vocabulary (
+        language_id,
+        word,
+        translation,
+        part_of_speech,
+        tone_marked,
+        audio_url,
+        example_sentence
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetVocabularyById :one
+SELECT * FROM vocabulary WHERE vocabulary_id = $1 LIMIT 1;
+
+-- name: ListVocabularyByLanguage :many
+SELECT *
+FROM vocabulary
+WHERE
+    language_id = $1
+ORDER BY word, translation
+LIMIT $2
+OFFSET $3;
+
+-- name: CountVocabularyByLanguage :one
+SELECT COUNT(*) FROM vocabulary WHERE language_id = $1;
+
+-- name: UpdateVocabulary :one
+UPDATE vocabulary
+SET
+    word = $1,
+    translation = $2,
+    part_of_speech = $3,
+    tone_marked = $4,
+    audio_url = $5,
+    example_sentence = $6
+WHERE
+    vocabulary_id = $7
+RETURNING *;
+
+-- name: DeleteVocabulary :execrows
+DELETE FROM vocabulary WHERE vocabulary_id = $1;
+
+-- name: GetExerciseLanguage :one
+SELECT c.language_id
+FROM exercises e
+    JOIN lessons l ON l.lesson_id = e.lesson_id
+    JOIN courses c ON c.course_id = l.course_id
+WHERE
+    e.exercise_id = $1
+LIMIT 1;
+
+-- name: CreateExerciseVocabulary :exec
+INSERT INTO
+    exercise_vocabulary (exercise_id, vocabulary_id)
+VALUES ($1, $2)
+ON CONFLICT (exercise_id, vocabulary_id) DO NOTHING;
+
+-- name: DeleteExerciseVocabulary :exec
+DELETE FROM exercise_vocabulary WHERE exercise_id = $1;
+
+-- name: ListExerciseVocabulary :many
+SELECT v.*
+FROM vocabulary v
+    JOIN exercise_vocabulary ev ON ev.vocabulary_id = v.vocabulary_id
+WHERE
+    ev.exercise_id = $1
+ORDER BY v.word;
+
+-- A word is learned once the learner completes any exercise that uses it
+-- name: ListLearnedVocabulary :many
+SELECT
+    v.vocabulary_id,
+    v.word,
+    v.translation,
+    v.part_of_speech,
+    v.tone_marked,
+    v.audio_url,
+    v.example_sentence,
+    MIN(up.completed_at)::TIMESTAMP AS learned_at
+FROM
+    vocabulary v
+    JOIN exercise_vocabulary ev ON ev.vocabulary_id = v.vocabulary_id
+    JOIN user_progress up ON up.exercise_id = ev.exercise_id
+    AND up.user_id = $1
+    AND up.is_completed = TRUE
+WHERE
+    v.language_id = $2
+GROUP BY
+    v.vocabulary_id
+ORDER BY learned_at DESC, v.word;
@@ -0,0 +1,61 @@
+-- Returns no rows if the award was already recorded
+-- name: CreateXPAward :one
+INSERT INTO
+    xp_ledger (
+        user_id,
+        source,
+        amount,
+        idempotency_key,
+        lesson_id,
+        awarded_at
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, idempotency_key) DO NOTHING
+RETURNING *;
+
+-- name: AddUserXP :one
+UPDATE users
+SET
+    xp_points = COALESCE(xp_points, 0) + sqlc.arg(amount)
+WHERE
+    user_id = sqlc.arg(user_id)
+RETURNING xp_points;
+
+-- name: GetLessonProgress :one
+SELECT
+    l.xp_reward,
+    COUNT(e.exercise_id) AS total_exercises,
+    COUNT(up.progress_id) FILTER (
+        WHERE
+            up.is_completed = TRUE
+    ) AS completed_exercises,
+    COUNT(up.progress_id) FILTER (
+        WHERE
+            up.score = 100
+    ) AS perfect_exercises
+FROM
+    lessons l
+    JOIN exercises e ON e.lesson_id = l.lesson_id
+    LEFT JOIN user_progress up ON up.exercise_id = e.exercise_id
+    AND up.user_id = $1
+WHERE
+    l.lesson_id = $2
+GROUP BY
+    l.lesson_id;
+
+-- XP per local day or week, most recent first
+-- name: ListXPHistory :many
+SELECT
+    date_trunc(
+        sqlc.arg(period)::TEXT,
+        (awarded_at AT TIME ZONE 'UTC') AT TIME ZONE sqlc.arg(timezone)::TEXT
+    )::DATE AS period_start,
+    SUM(amount)::BIGINT AS xp,
+    COUNT(*) AS awards
+FROM xp_ledger
+WHERE
+    user_id = sqlc.arg(user_id)
+    AND awarded_at >= sqlc.arg(since)
+GROUP BY
+    period_start
+ORDER BY period_start DESC;
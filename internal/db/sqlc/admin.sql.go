@@ -11,6 +11,17 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countSuperAdmins = `-- name: CountSuperAdmins :one
+SELECT COUNT(*) FROM admins WHERE role = 'super_admin'
+`
+
+func (q *Queries) CountSuperAdmins(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countSuperAdmins)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAdmin = `-- name: CreateAdmin :one
 INSERT INTO
     admins (
@@ -18,9 +29,10 @@ INSERT INTO
         last_name,
         email,
         password,
-        profile_image_url
+        profile_image_url,
+        role
     )
-VALUES ($1, $2, $3, $4, $5) RETURNING admin_id, first_name, last_name, email, password, profile_image_url, joined_at
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING admin_id, first_name, last_name, email, password, profile_image_url, joined_at, role, email_verified_at, verification_sent_at, totp_secret, totp_enabled_at, totp_last_step
 `
 
 type CreateAdminParams struct {
@@ -29,6 +41,7 @@ type CreateAdminParams struct {
 	Email           string      `json:"email"`
 	Password        string      `json:"password"`
 	ProfileImageUrl pgtype.Text `json:"profile_image_url"`
+	Role            string      `json:"role"`
 }
 
 func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin, error) {
@@ -38,6 +51,7 @@ func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin
 		arg.Email,
 		arg.Password,
 		arg.ProfileImageUrl,
+		arg.Role,
 	)
 	var i Admin
 	err := row.Scan(
@@ -48,6 +62,12 @@ func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin
 		&i.Password,
 		&i.ProfileImageUrl,
 		&i.JoinedAt,
+		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
 	)
 	return i, err
 }
@@ -61,7 +81,7 @@ INSERT INTO
         difficulty_level,
         is_free
     )
-VALUES ($1, $2, $3, $4, $5) RETURNING course_id, language_id, description, course_name, difficulty_level, is_free, created_at
+VALUES ($1, $2, $3, $4, $5) RETURNING course_id, language_id, description, course_name, difficulty_level, is_free, created_at, is_published
 `
 
 type CreateCourseParams struct {
@@ -89,6 +109,7 @@ func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (Cou
 		&i.DifficultyLevel,
 		&i.IsFree,
 		&i.CreatedAt,
+		&i.IsPublished,
 	)
 	return i, err
 }
@@ -180,17 +201,21 @@ INSERT INTO
         course_id,
         lesson_order,
         xp_reward,
-        is_unlocked
+        is_unlocked,
+        is_checkpoint,
+        unlock_score
     )
-VALUES ($1, $2, $3, $4, $5) RETURNING lesson_id, course_id, lesson_title, lesson_order, xp_reward, is_unlocked
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING lesson_id, course_id, lesson_title, lesson_order, xp_reward, is_unlocked, is_checkpoint, unlock_score
 `
 
 type CreateLessonParams struct {
-	LessonTitle string      `json:"lesson_title"`
-	CourseID    pgtype.UUID `json:"course_id"`
-	LessonOrder int32       `json:"lesson_order"`
-	XpReward    pgtype.Int4 `json:"xp_reward"`
-	IsUnlocked  pgtype.Bool `json:"is_unlocked"`
+	LessonTitle  string      `json:"lesson_title"`
+	CourseID     pgtype.UUID `json:"course_id"`
+	LessonOrder  int32       `json:"lesson_order"`
+	XpReward     pgtype.Int4 `json:"xp_reward"`
+	IsUnlocked   pgtype.Bool `json:"is_unlocked"`
+	IsCheckpoint bool        `json:"is_checkpoint"`
+	UnlockScore  pgtype.Int4 `json:"unlock_score"`
 }
 
 func (q *Queries) CreateLesson(ctx context.Context, arg CreateLessonParams) (Lesson, error) {
@@ -200,6 +225,8 @@ func (q *Queries) CreateLesson(ctx context.Context, arg CreateLessonParams) (Les
 		arg.LessonOrder,
 		arg.XpReward,
 		arg.IsUnlocked,
+		arg.IsCheckpoint,
+		arg.UnlockScore,
 	)
 	var i Lesson
 	err := row.Scan(
@@ -209,6 +236,8 @@ func (q *Queries) CreateLesson(ctx context.Context, arg CreateLessonParams) (Les
 		&i.LessonOrder,
 		&i.XpReward,
 		&i.IsUnlocked,
+		&i.IsCheckpoint,
+		&i.UnlockScore,
 	)
 	return i, err
 }
@@ -469,6 +498,7 @@ SELECT
     last_name,
     email,
     profile_image_url,
+    role,
     joined_at
 FROM admins
 WHERE
@@ -482,6 +512,7 @@ type GetAdminByIdRow struct {
 	LastName        string           `json:"last_name"`
 	Email           string           `json:"email"`
 	ProfileImageUrl pgtype.Text      `json:"profile_image_url"`
+	Role            string           `json:"role"`
 	JoinedAt        pgtype.Timestamp `json:"joined_at"`
 }
 
@@ -494,13 +525,49 @@ func (q *Queries) GetAdminById(ctx context.Context, adminID pgtype.UUID) (GetAdm
 		&i.LastName,
 		&i.Email,
 		&i.ProfileImageUrl,
+		&i.Role,
 		&i.JoinedAt,
 	)
 	return i, err
 }
 
+const getAdminCredentials = `-- name: GetAdminCredentials :one
+SELECT
+    admin_id,
+    first_name,
+    email,
+    password
+FROM admins
+WHERE
+    admin_id = $1
+LIMIT 1
+`
+
+type GetAdminCredentialsRow struct {
+	AdminID   pgtype.UUID `json:"admin_id"`
+	FirstName string      `json:"first_name"`
+	Email     string      `json:"email"`
+	Password  string      `json:"password"`
+}
+
+func (q *Queries) GetAdminCredentials(ctx context.Context, adminID pgtype.UUID) (GetAdminCredentialsRow, error) {
+	row := q.db.QueryRow(ctx, getAdminCredentials, adminID)
+	var i GetAdminCredentialsRow
+	err := row.Scan(
+		&i.AdminID,
+		&i.FirstName,
+		&i.Email,
+		&i.Password,
+	)
+	return i, err
+}
+
 const getAdminForLogin = `-- name: GetAdminForLogin :one
-SELECT admin_id, email, password
+SELECT
+    admin_id,
+    email,
+    password,
+    email_verified_at
 FROM admins
 WHERE
     email = $1
@@ -508,20 +575,37 @@ LIMIT 1
 `
 
 type GetAdminForLoginRow struct {
-	AdminID  pgtype.UUID `json:"admin_id"`
-	Email    string      `json:"email"`
-	Password string      `json:"password"`
+	AdminID         pgtype.UUID      `json:"admin_id"`
+	Email           string           `json:"email"`
+	Password        string           `json:"password"`
+	EmailVerifiedAt pgtype.Timestamp `json:"email_verified_at"`
 }
 
 func (q *Queries) GetAdminForLogin(ctx context.Context, email string) (GetAdminForLoginRow, error) {
 	row := q.db.QueryRow(ctx, getAdminForLogin, email)
 	var i GetAdminForLoginRow
-	err := row.Scan(&i.AdminID, &i.Email, &i.Password)
+	err := row.Scan(
+		&i.AdminID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+	)
 	return i, err
 }
 
+const getAdminRole = `-- name: GetAdminRole :one
+SELECT role FROM admins WHERE admin_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAdminRole(ctx context.Context, adminID pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getAdminRole, adminID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
 const getAllCourses = `-- name: GetAllCourses :many
-SELECT course_id, language_id, description, course_name, difficulty_level, is_free, created_at FROM courses
+SELECT course_id, language_id, description, course_name, difficulty_level, is_free, created_at, is_published FROM courses
 `
 
 func (q *Queries) GetAllCourses(ctx context.Context) ([]Course, error) {
@@ -541,6 +625,7 @@ func (q *Queries) GetAllCourses(ctx context.Context) ([]Course, error) {
 			&i.DifficultyLevel,
 			&i.IsFree,
 			&i.CreatedAt,
+			&i.IsPublished,
 		); err != nil {
 			return nil, err
 		}
@@ -553,7 +638,7 @@ func (q *Queries) GetAllCourses(ctx context.Context) ([]Course, error) {
 }
 
 const getAllCoursesByLanguage = `-- name: GetAllCoursesByLanguage :many
-SELECT course_id, language_id, description, course_name, difficulty_level, is_free, created_at FROM courses WHERE language_id = $1
+SELECT course_id, language_id, description, course_name, difficulty_level, is_free, created_at, is_published FROM courses WHERE language_id = $1
 `
 
 func (q *Queries) GetAllCoursesByLanguage(ctx context.Context, languageID pgtype.UUID) ([]Course, error) {
@@ -573,6 +658,7 @@ func (q *Queries) GetAllCoursesByLanguage(ctx context.Context, languageID pgtype
 			&i.DifficultyLevel,
 			&i.IsFree,
 			&i.CreatedAt,
+			&i.IsPublished,
 		); err != nil {
 			return nil, err
 		}
@@ -680,7 +766,7 @@ func (q *Queries) GetAllLanguages(ctx context.Context, arg GetAllLanguagesParams
 }
 
 const getAllLessons = `-- name: GetAllLessons :many
-SELECT lesson_id, course_id, lesson_title, lesson_order, xp_reward, is_unlocked FROM lessons LIMIT $1 OFFSET $2
+SELECT lesson_id, course_id, lesson_title, lesson_order, xp_reward, is_unlocked, is_checkpoint, unlock_score FROM lessons LIMIT $1 OFFSET $2
 `
 
 type GetAllLessonsParams struct {
@@ -704,6 +790,8 @@ func (q *Queries) GetAllLessons(ctx context.Context, arg GetAllLessonsParams) ([
 			&i.LessonOrder,
 			&i.XpReward,
 			&i.IsUnlocked,
+			&i.IsCheckpoint,
+			&i.UnlockScore,
 		); err != nil {
 			return nil, err
 		}
@@ -856,7 +944,9 @@ SELECT
     course_id,
     lesson_order,
     xp_reward,
-    is_unlocked
+    is_unlocked,
+    is_checkpoint,
+    unlock_score
 FROM lessons
 WHERE
     lesson_id = $1
@@ -864,12 +954,14 @@ LIMIT 1
 `
 
 type GetLessonByIdRow struct {
-	LessonID    pgtype.UUID `json:"lesson_id"`
-	LessonTitle string      `json:"lesson_title"`
-	CourseID    pgtype.UUID `json:"course_id"`
-	LessonOrder int32       `json:"lesson_order"`
-	XpReward    pgtype.Int4 `json:"xp_reward"`
-	IsUnlocked  pgtype.Bool `json:"is_unlocked"`
+	LessonID     pgtype.UUID `json:"lesson_id"`
+	LessonTitle  string      `json:"lesson_title"`
+	CourseID     pgtype.UUID `json:"course_id"`
+	LessonOrder  int32       `json:"lesson_order"`
+	XpReward     pgtype.Int4 `json:"xp_reward"`
+	IsUnlocked   pgtype.Bool `json:"is_unlocked"`
+	IsCheckpoint bool        `json:"is_checkpoint"`
+	UnlockScore  pgtype.Int4 `json:"unlock_score"`
 }
 
 func (q *Queries) GetLessonById(ctx context.Context, lessonID pgtype.UUID) (GetLessonByIdRow, error) {
@@ -882,6 +974,8 @@ func (q *Queries) GetLessonById(ctx context.Context, lessonID pgtype.UUID) (GetL
 		&i.LessonOrder,
 		&i.XpReward,
 		&i.IsUnlocked,
+		&i.IsCheckpoint,
+		&i.UnlockScore,
 	)
 	return i, err
 }
@@ -893,7 +987,9 @@ SELECT
     course_id,
     lesson_order,
     xp_reward,
-    is_unlocked
+    is_unlocked,
+    is_checkpoint,
+    unlock_score
 FROM lessons
 WHERE
     course_id = $1
@@ -910,12 +1006,14 @@ type GetLessonsByCourseIdParams struct {
 }
 
 type GetLessonsByCourseIdRow struct {
-	LessonID    pgtype.UUID `json:"lesson_id"`
-	LessonTitle string      `json:"lesson_title"`
-	CourseID    pgtype.UUID `json:"course_id"`
-	LessonOrder int32       `json:"lesson_order"`
-	XpReward    pgtype.Int4 `json:"xp_reward"`
-	IsUnlocked  pgtype.Bool `json:"is_unlocked"`
+	LessonID     pgtype.UUID `json:"lesson_id"`
+	LessonTitle  string      `json:"lesson_title"`
+	CourseID     pgtype.UUID `json:"course_id"`
+	LessonOrder  int32       `json:"lesson_order"`
+	XpReward     pgtype.Int4 `json:"xp_reward"`
+	IsUnlocked   pgtype.Bool `json:"is_unlocked"`
+	IsCheckpoint bool        `json:"is_checkpoint"`
+	UnlockScore  pgtype.Int4 `json:"unlock_score"`
 }
 
 func (q *Queries) GetLessonsByCourseId(ctx context.Context, arg GetLessonsByCourseIdParams) ([]GetLessonsByCourseIdRow, error) {
@@ -934,6 +1032,8 @@ func (q *Queries) GetLessonsByCourseId(ctx context.Context, arg GetLessonsByCour
 			&i.LessonOrder,
 			&i.XpReward,
 			&i.IsUnlocked,
+			&i.IsCheckpoint,
+			&i.UnlockScore,
 		); err != nil {
 			return nil, err
 		}
@@ -999,6 +1099,114 @@ func (q *Queries) GetUserProgressByUserId(ctx context.Context, arg GetUserProgre
 	return items, nil
 }
 
+const listAdmins = `-- name: ListAdmins :many
+SELECT
+    admin_id,
+    first_name,
+    last_name,
+    email,
+    role,
+    joined_at
+FROM admins
+ORDER BY joined_at ASC
+LIMIT $1
+OFFSET
+    $2
+`
+
+type ListAdminsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+type ListAdminsRow struct {
+	AdminID   pgtype.UUID      `json:"admin_id"`
+	FirstName string           `json:"first_name"`
+	LastName  string           `json:"last_name"`
+	Email     string           `json:"email"`
+	Role      string           `json:"role"`
+	JoinedAt  pgtype.Timestamp `json:"joined_at"`
+}
+
+func (q *Queries) ListAdmins(ctx context.Context, arg ListAdminsParams) ([]ListAdminsRow, error) {
+	rows, err := q.db.Query(ctx, listAdmins, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAdminsRow{}
+	for rows.Next() {
+		var i ListAdminsRow
+		if err := rows.Scan(
+			&i.AdminID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Role,
+			&i.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAdminEmailVerified = `-- name: MarkAdminEmailVerified :execrows
+UPDATE admins
+SET
+    email_verified_at = $1
+WHERE
+    admin_id = $2
+    AND email_verified_at IS NULL
+`
+
+type MarkAdminEmailVerifiedParams struct {
+	EmailVerifiedAt pgtype.Timestamp `json:"email_verified_at"`
+	AdminID         pgtype.UUID      `json:"admin_id"`
+}
+
+// Returns 0 rows affected if the email was already verified
+func (q *Queries) MarkAdminEmailVerified(ctx context.Context, arg MarkAdminEmailVerifiedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markAdminEmailVerified, arg.EmailVerifiedAt, arg.AdminID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reserveAdminVerificationEmail = `-- name: ReserveAdminVerificationEmail :execrows
+UPDATE admins
+SET
+    verification_sent_at = $1
+WHERE
+    admin_id = $2
+    AND email_verified_at IS NULL
+    AND (
+        verification_sent_at IS NULL
+        OR verification_sent_at < $3
+    )
+`
+
+type ReserveAdminVerificationEmailParams struct {
+	SentAt       pgtype.Timestamp `json:"sent_at"`
+	AdminID      pgtype.UUID      `json:"admin_id"`
+	ResendBefore pgtype.Timestamp `json:"resend_before"`
+}
+
+// Starts the resend cooldown for a verification email.
+// Returns 0 rows affected if the admin is already verified or was emailed too recently.
+func (q *Queries) ReserveAdminVerificationEmail(ctx context.Context, arg ReserveAdminVerificationEmailParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reserveAdminVerificationEmail, arg.SentAt, arg.AdminID, arg.ResendBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateAdmin = `-- name: UpdateAdmin :exec
 UPDATE admins
 SET
@@ -1043,7 +1251,15 @@ SET
     first_name = $1,
     last_name = $2,
     email = $3,
-    profile_image_url = $4
+    profile_image_url = $4,
+    email_verified_at = CASE
+        WHEN email = $3 THEN email_verified_at
+        ELSE NULL
+    END,
+    verification_sent_at = CASE
+        WHEN email = $3 THEN verification_sent_at
+        ELSE NULL
+    END
 WHERE
     admin_id = $5
 `
@@ -1082,6 +1298,21 @@ func (q *Queries) UpdateAdminPassword(ctx context.Context, arg UpdateAdminPasswo
 	return err
 }
 
+const updateAdminRole = `-- name: UpdateAdminRole :exec
+UPDATE admins SET role = $1 WHERE admin_id = $2
+`
+
+type UpdateAdminRoleParams struct {
+	Role    string      `json:"role"`
+	AdminID pgtype.UUID `json:"admin_id"`
+}
+
+// Update admin role
+func (q *Queries) UpdateAdminRole(ctx context.Context, arg UpdateAdminRoleParams) error {
+	_, err := q.db.Exec(ctx, updateAdminRole, arg.Role, arg.AdminID)
+	return err
+}
+
 const updateCourseDetails = `-- name: UpdateCourseDetails :exec
 UPDATE courses
 SET
@@ -1184,17 +1415,21 @@ SET
     lesson_title = $1,
     lesson_order = $2,
     xp_reward = $3,
-    is_unlocked = $4
+    is_unlocked = $4,
+    is_checkpoint = $5,
+    unlock_score = $6
 WHERE
-    lesson_id = $5
+    lesson_id = $7
 `
 
 type UpdateLessonDetailsParams struct {
-	LessonTitle string      `json:"lesson_title"`
-	LessonOrder int32       `json:"lesson_order"`
-	XpReward    pgtype.Int4 `json:"xp_reward"`
-	IsUnlocked  pgtype.Bool `json:"is_unlocked"`
-	LessonID    pgtype.UUID `json:"lesson_id"`
+	LessonTitle  string      `json:"lesson_title"`
+	LessonOrder  int32       `json:"lesson_order"`
+	XpReward     pgtype.Int4 `json:"xp_reward"`
+	IsUnlocked   pgtype.Bool `json:"is_unlocked"`
+	IsCheckpoint bool        `json:"is_checkpoint"`
+	UnlockScore  pgtype.Int4 `json:"unlock_score"`
+	LessonID     pgtype.UUID `json:"lesson_id"`
 }
 
 // Update lesson details
@@ -1204,6 +1439,8 @@ func (q *Queries) UpdateLessonDetails(ctx context.Context, arg UpdateLessonDetai
 		arg.LessonOrder,
 		arg.XpReward,
 		arg.IsUnlocked,
+		arg.IsCheckpoint,
+		arg.UnlockScore,
 		arg.LessonID,
 	)
 	return err
@@ -9,13 +9,38 @@ import (
 )
 
 type Admin struct {
-	AdminID         pgtype.UUID      `json:"admin_id"`
-	FirstName       string           `json:"first_name"`
-	LastName        string           `json:"last_name"`
-	Email           string           `json:"email"`
-	Password        string           `json:"password"`
-	ProfileImageUrl pgtype.Text      `json:"profile_image_url"`
-	JoinedAt        pgtype.Timestamp `json:"joined_at"`
+	AdminID            pgtype.UUID      `json:"admin_id"`
+	FirstName          string           `json:"first_name"`
+	LastName           string           `json:"last_name"`
+	Email              string           `json:"email"`
+	Password           string           `json:"password"`
+	ProfileImageUrl    pgtype.Text      `json:"profile_image_url"`
+	JoinedAt           pgtype.Timestamp `json:"joined_at"`
+	Role               string           `json:"role"`
+	EmailVerifiedAt    pgtype.Timestamp `json:"email_verified_at"`
+	VerificationSentAt pgtype.Timestamp `json:"verification_sent_at"`
+	TotpSecret         pgtype.Text      `json:"totp_secret"`
+	TotpEnabledAt      pgtype.Timestamp `json:"totp_enabled_at"`
+	TotpLastStep       pgtype.Int8      `json:"totp_last_step"`
+}
+
+type AdminInvite struct {
+	InviteID  pgtype.UUID      `json:"invite_id"`
+	Email     string           `json:"email"`
+	Role      string           `json:"role"`
+	TokenHash string           `json:"token_hash"`
+	InvitedBy pgtype.UUID      `json:"invited_by"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type AdminRecoveryCode struct {
+	CodeID    pgtype.UUID      `json:"code_id"`
+	AdminID   pgtype.UUID      `json:"admin_id"`
+	CodeHash  string           `json:"code_hash"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
 
 type Course struct {
@@ -26,6 +51,7 @@ type Course struct {
 	DifficultyLevel pgtype.Text      `json:"difficulty_level"`
 	IsFree          pgtype.Bool      `json:"is_free"`
 	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	IsPublished     bool             `json:"is_published"`
 }
 
 type Exercise struct {
@@ -38,6 +64,25 @@ type Exercise struct {
 	AudioUrl      pgtype.Text `json:"audio_url"`
 }
 
+type ExerciseVocabulary struct {
+	ExerciseID   pgtype.UUID `json:"exercise_id"`
+	VocabularyID pgtype.UUID `json:"vocabulary_id"`
+}
+
+type Friendship struct {
+	UserID    pgtype.UUID      `json:"user_id"`
+	FriendID  pgtype.UUID      `json:"friend_id"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type GlobalLeaderboard struct {
+	UserID          pgtype.UUID `json:"user_id"`
+	Username        string      `json:"username"`
+	ProfileImageUrl pgtype.Text `json:"profile_image_url"`
+	Xp              int32       `json:"xp"`
+	Rank            int64       `json:"rank"`
+}
+
 type Language struct {
 	LanguageID   pgtype.UUID `json:"language_id"`
 	LanguageName string      `json:"language_name"`
@@ -46,25 +91,111 @@ type Language struct {
 	Description  pgtype.Text `json:"description"`
 }
 
+type LanguageLeaderboard struct {
+	LanguageID      pgtype.UUID `json:"language_id"`
+	UserID          pgtype.UUID `json:"user_id"`
+	Username        string      `json:"username"`
+	ProfileImageUrl pgtype.Text `json:"profile_image_url"`
+	Xp              int64       `json:"xp"`
+	Rank            int64       `json:"rank"`
+}
+
+type League struct {
+	LeagueID    pgtype.UUID      `json:"league_id"`
+	Tier        int32            `json:"tier"`
+	WeekStart   pgtype.Date      `json:"week_start"`
+	MemberCount int32            `json:"member_count"`
+	SettledAt   pgtype.Timestamp `json:"settled_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type LeagueMember struct {
+	LeagueID  pgtype.UUID      `json:"league_id"`
+	UserID    pgtype.UUID      `json:"user_id"`
+	WeekStart pgtype.Date      `json:"week_start"`
+	Xp        int32            `json:"xp"`
+	FinalRank pgtype.Int4      `json:"final_rank"`
+	Outcome   pgtype.Text      `json:"outcome"`
+	JoinedAt  pgtype.Timestamp `json:"joined_at"`
+}
+
 type Lesson struct {
-	LessonID    pgtype.UUID `json:"lesson_id"`
-	CourseID    pgtype.UUID `json:"course_id"`
-	LessonTitle string      `json:"lesson_title"`
-	LessonOrder int32       `json:"lesson_order"`
-	XpReward    pgtype.Int4 `json:"xp_reward"`
-	IsUnlocked  pgtype.Bool `json:"is_unlocked"`
+	LessonID     pgtype.UUID `json:"lesson_id"`
+	CourseID     pgtype.UUID `json:"course_id"`
+	LessonTitle  string      `json:"lesson_title"`
+	LessonOrder  int32       `json:"lesson_order"`
+	XpReward     pgtype.Int4 `json:"xp_reward"`
+	IsUnlocked   pgtype.Bool `json:"is_unlocked"`
+	IsCheckpoint bool        `json:"is_checkpoint"`
+	UnlockScore  pgtype.Int4 `json:"unlock_score"`
+}
+
+type LoginThrottle struct {
+	Scope        string           `json:"scope"`
+	ThrottleKey  string           `json:"throttle_key"`
+	FailedCount  int32            `json:"failed_count"`
+	LastFailedAt pgtype.Timestamp `json:"last_failed_at"`
+	LockedUntil  pgtype.Timestamp `json:"locked_until"`
+}
+
+type PasswordReset struct {
+	ResetID   pgtype.UUID      `json:"reset_id"`
+	OwnerID   pgtype.UUID      `json:"owner_id"`
+	OwnerRole string           `json:"owner_role"`
+	TokenHash string           `json:"token_hash"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type ReviewItem struct {
+	UserID         pgtype.UUID      `json:"user_id"`
+	ExerciseID     pgtype.UUID      `json:"exercise_id"`
+	EaseFactor     float64          `json:"ease_factor"`
+	IntervalDays   int32            `json:"interval_days"`
+	Repetitions    int32            `json:"repetitions"`
+	DueAt          pgtype.Timestamp `json:"due_at"`
+	LastReviewedAt pgtype.Timestamp `json:"last_reviewed_at"`
+}
+
+type Session struct {
+	SessionID        pgtype.UUID      `json:"session_id"`
+	FamilyID         pgtype.UUID      `json:"family_id"`
+	OwnerID          pgtype.UUID      `json:"owner_id"`
+	OwnerRole        string           `json:"owner_role"`
+	RefreshTokenHash string           `json:"refresh_token_hash"`
+	UserAgent        string           `json:"user_agent"`
+	ClientIp         string           `json:"client_ip"`
+	IsRevoked        bool             `json:"is_revoked"`
+	RotatedAt        pgtype.Timestamp `json:"rotated_at"`
+	ExpiresAt        pgtype.Timestamp `json:"expires_at"`
+	CreatedAt        pgtype.Timestamp `json:"created_at"`
+}
+
+type StreakDay struct {
+	UserID     pgtype.UUID      `json:"user_id"`
+	StreakDate pgtype.Date      `json:"streak_date"`
+	Status     string           `json:"status"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
 }
 
 type User struct {
-	UserID          pgtype.UUID      `json:"user_id"`
-	Username        string           `json:"username"`
-	Email           string           `json:"email"`
-	Password        string           `json:"password"`
-	ProfileImageUrl pgtype.Text      `json:"profile_image_url"`
-	StreakCount     pgtype.Int4      `json:"streak_count"`
-	XpPoints        pgtype.Int4      `json:"xp_points"`
-	LastActiveDate  pgtype.Timestamp `json:"last_active_date"`
-	JoinedAt        pgtype.Timestamp `json:"joined_at"`
+	UserID             pgtype.UUID      `json:"user_id"`
+	Username           string           `json:"username"`
+	Email              string           `json:"email"`
+	Password           string           `json:"password"`
+	ProfileImageUrl    pgtype.Text      `json:"profile_image_url"`
+	StreakCount        pgtype.Int4      `json:"streak_count"`
+	XpPoints           pgtype.Int4      `json:"xp_points"`
+	LastActiveDate     pgtype.Timestamp `json:"last_active_date"`
+	JoinedAt           pgtype.Timestamp `json:"joined_at"`
+	EmailVerifiedAt    pgtype.Timestamp `json:"email_verified_at"`
+	VerificationSentAt pgtype.Timestamp `json:"verification_sent_at"`
+	Timezone           string           `json:"timezone"`
+	LongestStreak      int32            `json:"longest_streak"`
+	StreakFreezes      int32            `json:"streak_freezes"`
+	LastStreakDate     pgtype.Date      `json:"last_streak_date"`
+	LeagueTier         int32            `json:"league_tier"`
 }
 
 type UserCourse struct {
@@ -84,3 +215,25 @@ type UserProgress struct {
 	Score       pgtype.Int4      `json:"score"`
 	CompletedAt pgtype.Timestamp `json:"completed_at"`
 }
+
+type Vocabulary struct {
+	VocabularyID    pgtype.UUID      `json:"vocabulary_id"`
+	LanguageID      pgtype.UUID      `json:"language_id"`
+	Word            string           `json:"word"`
+	Translation     string           `json:"translation"`
+	PartOfSpeech    pgtype.Text      `json:"part_of_speech"`
+	ToneMarked      pgtype.Text      `json:"tone_marked"`
+	AudioUrl        pgtype.Text      `json:"audio_url"`
+	ExampleSentence pgtype.Text      `json:"example_sentence"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+}
+
+type XpLedger struct {
+	EntryID        pgtype.UUID      `json:"entry_id"`
+	UserID         pgtype.UUID      `json:"user_id"`
+	Source         string           `json:"source"`
+	Amount         int32            `json:"amount"`
+	IdempotencyKey string           `json:"idempotency_key"`
+	LessonID       pgtype.UUID      `json:"lesson_id"`
+	AwardedAt      pgtype.Timestamp `json:"awarded_at"`
+}
@@ -11,31 +11,58 @@ import (
 )
 
 type Querier interface {
+	// Adds XP to the learner's league for the week, if they have joined one
+	AddLeagueXP(ctx context.Context, arg AddLeagueXPParams) (int64, error)
+	AddUserXP(ctx context.Context, arg AddUserXPParams) (pgtype.Int4, error)
+	CountAdminRecoveryCodes(ctx context.Context, adminID pgtype.UUID) (int64, error)
+	CountDueReviews(ctx context.Context, arg CountDueReviewsParams) (int64, error)
+	CountFriendships(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountSuperAdmins(ctx context.Context) (int64, error)
+	CountVocabularyByLanguage(ctx context.Context, languageID pgtype.UUID) (int64, error)
 	CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin, error)
+	CreateAdminInvite(ctx context.Context, arg CreateAdminInviteParams) (AdminInvite, error)
+	CreateAdminRecoveryCode(ctx context.Context, arg CreateAdminRecoveryCodeParams) error
 	CreateCourse(ctx context.Context, arg CreateCourseParams) (Course, error)
 	CreateExercise(ctx context.Context, arg CreateExerciseParams) (Exercise, error)
+	CreateExerciseVocabulary(ctx context.Context, arg CreateExerciseVocabularyParams) error
+	CreateFriendship(ctx context.Context, arg CreateFriendshipParams) error
 	CreateLanguage(ctx context.Context, arg CreateLanguageParams) (Language, error)
+	CreateLeague(ctx context.Context, arg CreateLeagueParams) (League, error)
+	CreateLeagueMember(ctx context.Context, arg CreateLeagueMemberParams) (LeagueMember, error)
 	CreateLesson(ctx context.Context, arg CreateLessonParams) (Lesson, error)
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
 	CreateUserCourse(ctx context.Context, arg CreateUserCourseParams) (UserCourse, error)
 	CreateUserProgress(ctx context.Context, arg CreateUserProgressParams) (UserProgress, error)
+	CreateVocabulary(ctx context.Context, arg CreateVocabularyParams) (Vocabulary, error)
+	// Returns no rows if the award was already recorded
+	CreateXPAward(ctx context.Context, arg CreateXPAwardParams) (XpLedger, error)
 	// Delete admin by ID
 	DeleteAdmin(ctx context.Context, adminID pgtype.UUID) error
+	DeleteAdminRecoveryCodes(ctx context.Context, adminID pgtype.UUID) error
 	// Delete course by ID
 	DeleteCourse(ctx context.Context, courseID pgtype.UUID) error
 	// Delete courses by language ID
 	DeleteCoursesByLanguageId(ctx context.Context, languageID pgtype.UUID) error
 	// Delete exercise by ID
 	DeleteExercise(ctx context.Context, exerciseID pgtype.UUID) error
+	DeleteExerciseVocabulary(ctx context.Context, exerciseID pgtype.UUID) error
 	// Delete exercises by lesson ID
 	DeleteExercisesByLessonId(ctx context.Context, lessonID pgtype.UUID) error
+	DeleteFriendship(ctx context.Context, arg DeleteFriendshipParams) (int64, error)
 	// Delete language by ID
 	DeleteLanguage(ctx context.Context, languageID pgtype.UUID) error
 	// Delete lesson by ID
 	DeleteLesson(ctx context.Context, lessonID pgtype.UUID) error
 	// Delete lessons by course ID
 	DeleteLessonsByCourseId(ctx context.Context, courseID pgtype.UUID) error
+	// Clears the failed logins and any lock of an account or IP
+	DeleteLoginThrottle(ctx context.Context, arg DeleteLoginThrottleParams) (int64, error)
 	// Delete user by ID
 	DeleteUser(ctx context.Context, userID pgtype.UUID) error
+	// Returns 0 rows affected if the user wasn't enrolled
+	DeleteUserCourse(ctx context.Context, arg DeleteUserCourseParams) (int64, error)
 	// Delete user courses by course ID
 	DeleteUserCoursesByCourseId(ctx context.Context, courseID pgtype.UUID) error
 	// Delete user courses by user ID
@@ -46,9 +73,18 @@ type Querier interface {
 	DeleteUserProgressByLessonId(ctx context.Context, lessonID pgtype.UUID) error
 	// Delete user progress by user ID
 	DeleteUserProgressByUserId(ctx context.Context, userID pgtype.UUID) error
+	DeleteVocabulary(ctx context.Context, vocabularyID pgtype.UUID) (int64, error)
+	DisableAdminTotp(ctx context.Context, adminID pgtype.UUID) error
+	// Returns 0 rows affected if there is no pending secret or it was already enabled
+	EnableAdminTotp(ctx context.Context, arg EnableAdminTotpParams) (int64, error)
 	GetAdminByEmail(ctx context.Context, email string) (GetAdminByEmailRow, error)
 	GetAdminById(ctx context.Context, adminID pgtype.UUID) (GetAdminByIdRow, error)
+	GetAdminCredentials(ctx context.Context, adminID pgtype.UUID) (GetAdminCredentialsRow, error)
 	GetAdminForLogin(ctx context.Context, email string) (GetAdminForLoginRow, error)
+	// Locks the invite so two signups can't redeem it at the same time
+	GetAdminInviteForUpdate(ctx context.Context, tokenHash string) (AdminInvite, error)
+	GetAdminRole(ctx context.Context, adminID pgtype.UUID) (string, error)
+	GetAdminTwoFactor(ctx context.Context, adminID pgtype.UUID) (GetAdminTwoFactorRow, error)
 	GetAllCourses(ctx context.Context) ([]Course, error)
 	GetAllCoursesByLanguage(ctx context.Context, languageID pgtype.UUID) ([]Course, error)
 	GetAllExercises(ctx context.Context, arg GetAllExercisesParams) ([]Exercise, error)
@@ -56,16 +92,114 @@ type Querier interface {
 	GetAllLanguages(ctx context.Context, arg GetAllLanguagesParams) ([]Language, error)
 	GetAllLessons(ctx context.Context, arg GetAllLessonsParams) ([]Lesson, error)
 	GetExerciseById(ctx context.Context, exerciseID pgtype.UUID) (Exercise, error)
+	GetExerciseForGrading(ctx context.Context, exerciseID pgtype.UUID) (GetExerciseForGradingRow, error)
+	GetExerciseLanguage(ctx context.Context, exerciseID pgtype.UUID) (pgtype.UUID, error)
 	GetExercisesByLessonId(ctx context.Context, arg GetExercisesByLessonIdParams) ([]Exercise, error)
+	GetGlobalLeaderboardEntry(ctx context.Context, userID pgtype.UUID) (GlobalLeaderboard, error)
 	GetLanguageById(ctx context.Context, languageID pgtype.UUID) (Language, error)
 	GetLanguageByName(ctx context.Context, languageName string) (Language, error)
+	GetLanguageLeaderboardEntry(ctx context.Context, arg GetLanguageLeaderboardEntryParams) (LanguageLeaderboard, error)
 	GetLessonById(ctx context.Context, lessonID pgtype.UUID) (GetLessonByIdRow, error)
+	GetLessonProgress(ctx context.Context, arg GetLessonProgressParams) (GetLessonProgressRow, error)
 	GetLessonsByCourseId(ctx context.Context, arg GetLessonsByCourseIdParams) ([]GetLessonsByCourseIdRow, error)
+	GetLoginThrottle(ctx context.Context, arg GetLoginThrottleParams) (LoginThrottle, error)
+	// Finds the oldest league of a tier with room left, skipping leagues other learners are joining
+	GetOpenLeagueForUpdate(ctx context.Context, arg GetOpenLeagueForUpdateParams) (League, error)
+	// Locks the reset so the same code can't be redeemed twice concurrently
+	GetPasswordResetForUpdate(ctx context.Context, tokenHash string) (PasswordReset, error)
+	GetPublishedCourse(ctx context.Context, courseID pgtype.UUID) (Course, error)
+	GetReviewItemForUpdate(ctx context.Context, arg GetReviewItemForUpdateParams) (ReviewItem, error)
+	GetSession(ctx context.Context, sessionID pgtype.UUID) (Session, error)
+	// Locks a league for settling, returning no rows if it's already settled
+	GetUnsettledLeagueForUpdate(ctx context.Context, leagueID pgtype.UUID) (League, error)
+	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
+	GetUserById(ctx context.Context, userID pgtype.UUID) (GetUserByIdRow, error)
+	GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error)
+	GetUserCourse(ctx context.Context, arg GetUserCourseParams) (UserCourse, error)
+	GetUserForLogin(ctx context.Context, email string) (GetUserForLoginRow, error)
+	GetUserLeague(ctx context.Context, arg GetUserLeagueParams) (League, error)
+	GetUserLeagueResult(ctx context.Context, arg GetUserLeagueResultParams) (GetUserLeagueResultRow, error)
+	GetUserLeagueTier(ctx context.Context, userID pgtype.UUID) (int32, error)
 	GetUserProgressByUserId(ctx context.Context, arg GetUserProgressByUserIdParams) ([]GetUserProgressByUserIdRow, error)
+	GetUserStreak(ctx context.Context, userID pgtype.UUID) (GetUserStreakRow, error)
+	// Locks the learner's row so concurrent activities only extend the streak once
+	GetUserStreakForUpdate(ctx context.Context, userID pgtype.UUID) (GetUserStreakForUpdateRow, error)
+	GetVocabularyById(ctx context.Context, vocabularyID pgtype.UUID) (Vocabulary, error)
+	IncrementLeagueMembers(ctx context.Context, leagueID pgtype.UUID) error
+	// Marks every outstanding reset of an admin or learner as used
+	InvalidatePasswordResets(ctx context.Context, arg InvalidatePasswordResetsParams) error
+	IsAdminTotpEnabled(ctx context.Context, adminID pgtype.UUID) (bool, error)
+	ListAdmins(ctx context.Context, arg ListAdminsParams) ([]ListAdminsRow, error)
+	// A learner's progress on every lesson of a course, in lesson order
+	ListCourseLessonProgress(ctx context.Context, arg ListCourseLessonProgressParams) ([]ListCourseLessonProgressRow, error)
+	// Due exercises from the learner's enrolled courses in a language, most overdue first
+	ListDueReviews(ctx context.Context, arg ListDueReviewsParams) ([]ListDueReviewsRow, error)
+	ListExerciseVocabulary(ctx context.Context, exerciseID pgtype.UUID) ([]Vocabulary, error)
+	// Ranks the learner and their friends live, as the group is small
+	ListFriendsLeaderboard(ctx context.Context, userID pgtype.UUID) ([]ListFriendsLeaderboardRow, error)
+	ListGlobalLeaderboard(ctx context.Context, arg ListGlobalLeaderboardParams) ([]GlobalLeaderboard, error)
+	ListLanguageLeaderboard(ctx context.Context, arg ListLanguageLeaderboardParams) ([]LanguageLeaderboard, error)
+	ListLeagueStandings(ctx context.Context, leagueID pgtype.UUID) ([]ListLeagueStandingsRow, error)
+	// A word is learned once the learner completes any exercise that uses it
+	ListLearnedVocabulary(ctx context.Context, arg ListLearnedVocabularyParams) ([]ListLearnedVocabularyRow, error)
+	// Exercises as shown to learners, without the correct answer
+	ListLessonExercises(ctx context.Context, lessonID pgtype.UUID) ([]ListLessonExercisesRow, error)
+	ListPublishedCoursesByLanguage(ctx context.Context, languageID pgtype.UUID) ([]Course, error)
+	ListStreakDays(ctx context.Context, arg ListStreakDaysParams) ([]ListStreakDaysRow, error)
+	// Leagues from before the given week that the learner was in and that haven't been settled yet, oldest first
+	ListUnsettledUserLeagues(ctx context.Context, arg ListUnsettledUserLeaguesParams) ([]pgtype.UUID, error)
+	// Courses a user is enrolled in, most recent first
+	ListUserCourses(ctx context.Context, userID pgtype.UUID) ([]ListUserCoursesRow, error)
+	ListVocabularyByLanguage(ctx context.Context, arg ListVocabularyByLanguageParams) ([]Vocabulary, error)
+	// XP per local day or week, most recent first
+	ListXPHistory(ctx context.Context, arg ListXPHistoryParams) ([]ListXPHistoryRow, error)
+	LockLogin(ctx context.Context, arg LockLoginParams) error
+	// Returns 0 rows affected if the email was already verified
+	MarkAdminEmailVerified(ctx context.Context, arg MarkAdminEmailVerifiedParams) (int64, error)
+	MarkAdminInviteUsed(ctx context.Context, inviteID pgtype.UUID) (int64, error)
+	// Marks a session as used so its refresh token can't be presented again.
+	// Returns 0 rows affected if the session was already rotated or revoked.
+	MarkSessionRotated(ctx context.Context, sessionID pgtype.UUID) (int64, error)
+	// Returns 0 rows affected if the email was already verified
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (int64, error)
+	// Keeps the best score and the first completion across attempts
+	RecordExerciseAttempt(ctx context.Context, arg RecordExerciseAttemptParams) (UserProgress, error)
+	// Counts a failed login, starting over if the previous failure is older than reset_before
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginThrottle, error)
+	RecordStreakDay(ctx context.Context, arg RecordStreakDayParams) error
+	// Recomputes a learner's completion of a course from the exercises they have completed
+	RefreshCourseCompletion(ctx context.Context, arg RefreshCourseCompletionParams) (pgtype.Float8, error)
+	// Recomputes the completion of every learner enrolled in a course after its exercises change
+	RefreshCourseCompletionForCourse(ctx context.Context, courseID pgtype.UUID) error
+	// Rebuilds the global leaderboard without blocking reads
+	RefreshGlobalLeaderboard(ctx context.Context) error
+	// Rebuilds the language leaderboards without blocking reads
+	RefreshLanguageLeaderboard(ctx context.Context) error
+	// Starts the resend cooldown for a verification email.
+	// Returns 0 rows affected if the admin is already verified or was emailed too recently.
+	ReserveAdminVerificationEmail(ctx context.Context, arg ReserveAdminVerificationEmailParams) (int64, error)
+	// Starts the resend cooldown for a verification email.
+	// Returns 0 rows affected if the user is already verified or was emailed too recently.
+	ReserveUserVerificationEmail(ctx context.Context, arg ReserveUserVerificationEmailParams) (int64, error)
+	// Revoke every session descended from the same login
+	RevokeSessionFamily(ctx context.Context, familyID pgtype.UUID) error
+	// Revoke every session of an admin or learner
+	RevokeSessionsByOwner(ctx context.Context, arg RevokeSessionsByOwnerParams) error
+	// Stores a new secret awaiting confirmation.
+	// Returns 0 rows affected if two-factor authentication is already enabled.
+	SetAdminTotpSecret(ctx context.Context, arg SetAdminTotpSecretParams) (int64, error)
+	// Returns 0 rows affected if the course doesn't exist
+	SetCoursePublished(ctx context.Context, arg SetCoursePublishedParams) (int64, error)
+	SetUserLeagueTier(ctx context.Context, arg SetUserLeagueTierParams) error
+	SetUserTimezone(ctx context.Context, arg SetUserTimezoneParams) error
+	SettleLeague(ctx context.Context, arg SettleLeagueParams) error
+	SettleLeagueMember(ctx context.Context, arg SettleLeagueMemberParams) error
 	UpdateAdmin(ctx context.Context, arg UpdateAdminParams) error
 	UpdateAdminDetails(ctx context.Context, arg UpdateAdminDetailsParams) error
 	// Update admin password
 	UpdateAdminPassword(ctx context.Context, arg UpdateAdminPasswordParams) error
+	// Update admin role
+	UpdateAdminRole(ctx context.Context, arg UpdateAdminRoleParams) error
 	// Update course details
 	UpdateCourseDetails(ctx context.Context, arg UpdateCourseDetailsParams) error
 	// Update exercise details
@@ -76,8 +210,20 @@ type Querier interface {
 	UpdateLessonDetails(ctx context.Context, arg UpdateLessonDetailsParams) error
 	// Update user course progress
 	UpdateUserCourseProgress(ctx context.Context, arg UpdateUserCourseProgressParams) error
+	// Update user password
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	// Changing the email clears its verification
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UpdateUserProfileRow, error)
 	// Update user progress
 	UpdateUserProgress(ctx context.Context, arg UpdateUserProgressParams) error
+	UpdateUserStreak(ctx context.Context, arg UpdateUserStreakParams) error
+	UpdateVocabulary(ctx context.Context, arg UpdateVocabularyParams) (Vocabulary, error)
+	UpsertReviewItem(ctx context.Context, arg UpsertReviewItemParams) (ReviewItem, error)
+	// Returns 0 rows affected if the code doesn't exist or was already used
+	UseAdminRecoveryCode(ctx context.Context, arg UseAdminRecoveryCodeParams) (int64, error)
+	// Records the time step of a used code so it can't be replayed.
+	// Returns 0 rows affected if a code from this or a later step was already used.
+	UseAdminTotpStep(ctx context.Context, arg UseAdminTotpStepParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
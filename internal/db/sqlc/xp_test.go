@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"lingo/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// testTx begins a transaction on the database in app.env that is rolled back
+// when the test ends. The test is skipped if no database is available.
+func testTx(t *testing.T) pgx.Tx {
+	config, err := utils.LoadConfig("../../..")
+	if err != nil || config.DBSource == "" {
+		t.Skip("no database configured in app.env")
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, config.DBSource)
+	if err != nil {
+		t.Skipf("database unavailable: %v", err)
+	}
+	t.Cleanup(func() { conn.Close(ctx) })
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { tx.Rollback(ctx) })
+	return tx
+}
+
+func TestDeleteLessonKeepsXPAwards(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	q := New(tx)
+	suffix := fmt.Sprintf("%05d", rand.IntN(100000))
+
+	language, err := q.CreateLanguage(ctx, CreateLanguageParams{
+		LanguageName: "XP ledger test",
+		LanguageCode: suffix,
+	})
+	require.NoError(t, err)
+	course, err := q.CreateCourse(ctx, CreateCourseParams{
+		CourseName: "XP ledger test",
+		LanguageID: language.LanguageID,
+	})
+	require.NoError(t, err)
+	lesson, err := q.CreateLesson(ctx, CreateLessonParams{
+		LessonTitle: "XP ledger test",
+		CourseID:    course.CourseID,
+		LessonOrder: 1,
+	})
+	require.NoError(t, err)
+	user, err := q.CreateUser(ctx, CreateUserParams{
+		Username: "xp_test_" + suffix,
+		Email:    "xp_test_" + suffix + "@example.com",
+		Password: "password",
+	})
+	require.NoError(t, err)
+
+	award, err := q.CreateXPAward(ctx, CreateXPAwardParams{
+		UserID:         user.UserID,
+		Source:         "lesson_completion",
+		Amount:         10,
+		IdempotencyKey: "lesson:" + lesson.LessonID.String(),
+		LessonID:       lesson.LessonID,
+		AwardedAt:      pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	// Deleting the lesson clears lesson_id on its awards but keeps them
+	require.NoError(t, q.DeleteLesson(ctx, lesson.LessonID))
+
+	var lessonID pgtype.UUID
+	var amount int32
+	err = tx.QueryRow(ctx, "SELECT lesson_id, amount FROM xp_ledger WHERE entry_id = $1", award.EntryID).Scan(&lessonID, &amount)
+	require.NoError(t, err)
+	require.False(t, lessonID.Valid)
+	require.Equal(t, int32(10), amount)
+
+	// Any other change is still rejected
+	_, err = tx.Exec(ctx, "UPDATE xp_ledger SET amount = 20 WHERE entry_id = $1", award.EntryID)
+	require.ErrorContains(t, err, "xp_ledger is append-only")
+}
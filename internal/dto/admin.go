@@ -0,0 +1,55 @@
+package dto
+
+import db "lingo/internal/db/sqlc"
+
+// Admin is an admin account as seen by other admins
+type Admin struct {
+	AdminID          string  `json:"admin_id"`
+	FirstName        string  `json:"first_name"`
+	LastName         string  `json:"last_name"`
+	Email            string  `json:"email"`
+	ProfileImageUrl  *string `json:"profile_image_url"`
+	Role             string  `json:"role"`
+	JoinedAt         *string `json:"joined_at"`
+	EmailVerifiedAt  *string `json:"email_verified_at"`
+	TwoFactorEnabled bool    `json:"two_factor_enabled"`
+}
+
+func NewAdmin(a db.Admin) Admin {
+	return Admin{
+		AdminID:          uuidString(a.AdminID),
+		FirstName:        a.FirstName,
+		LastName:         a.LastName,
+		Email:            a.Email,
+		ProfileImageUrl:  nullableString(a.ProfileImageUrl),
+		Role:             a.Role,
+		JoinedAt:         timestamp(a.JoinedAt),
+		EmailVerifiedAt:  timestamp(a.EmailVerifiedAt),
+		TwoFactorEnabled: a.TotpEnabledAt.Valid,
+	}
+}
+
+// AdminSummary is an entry in the list of admins
+type AdminSummary struct {
+	AdminID   string  `json:"admin_id"`
+	FirstName string  `json:"first_name"`
+	LastName  string  `json:"last_name"`
+	Email     string  `json:"email"`
+	Role      string  `json:"role"`
+	JoinedAt  *string `json:"joined_at"`
+}
+
+func NewAdminSummaries(rows []db.ListAdminsRow) []AdminSummary {
+	admins := make([]AdminSummary, 0, len(rows))
+	for _, a := range rows {
+		admins = append(admins, AdminSummary{
+			AdminID:   uuidString(a.AdminID),
+			FirstName: a.FirstName,
+			LastName:  a.LastName,
+			Email:     a.Email,
+			Role:      a.Role,
+			JoinedAt:  timestamp(a.JoinedAt),
+		})
+	}
+	return admins
+}
@@ -0,0 +1,189 @@
+package dto
+
+import (
+	"encoding/json"
+	db "lingo/internal/db/sqlc"
+)
+
+type Language struct {
+	LanguageID   string  `json:"language_id"`
+	LanguageName string  `json:"language_name"`
+	LanguageCode string  `json:"language_code"`
+	FlagEmoji    *string `json:"flag_emoji"`
+	Description  *string `json:"description"`
+}
+
+func NewLanguage(l db.Language) Language {
+	return Language{
+		LanguageID:   uuidString(l.LanguageID),
+		LanguageName: l.LanguageName,
+		LanguageCode: l.LanguageCode,
+		FlagEmoji:    nullableString(l.FlagEmoji),
+		Description:  nullableString(l.Description),
+	}
+}
+
+func NewLanguages(rows []db.Language) []Language {
+	languages := make([]Language, 0, len(rows))
+	for _, l := range rows {
+		languages = append(languages, NewLanguage(l))
+	}
+	return languages
+}
+
+type Course struct {
+	CourseID        string  `json:"course_id"`
+	LanguageID      string  `json:"language_id"`
+	CourseName      string  `json:"course_name"`
+	Description     *string `json:"description"`
+	DifficultyLevel *string `json:"difficulty_level"`
+	IsFree          bool    `json:"is_free"`
+	IsPublished     bool    `json:"is_published"`
+	CreatedAt       *string `json:"created_at"`
+}
+
+func NewCourse(c db.Course) Course {
+	return Course{
+		CourseID:        uuidString(c.CourseID),
+		LanguageID:      uuidString(c.LanguageID),
+		CourseName:      c.CourseName,
+		Description:     nullableString(c.Description),
+		DifficultyLevel: nullableString(c.DifficultyLevel),
+		IsFree:          c.IsFree.Bool,
+		IsPublished:     c.IsPublished,
+		CreatedAt:       timestamp(c.CreatedAt),
+	}
+}
+
+func NewCourses(rows []db.Course) []Course {
+	courses := make([]Course, 0, len(rows))
+	for _, c := range rows {
+		courses = append(courses, NewCourse(c))
+	}
+	return courses
+}
+
+type Lesson struct {
+	LessonID     string `json:"lesson_id"`
+	CourseID     string `json:"course_id"`
+	LessonTitle  string `json:"lesson_title"`
+	LessonOrder  int32  `json:"lesson_order"`
+	XpReward     int32  `json:"xp_reward"`
+	IsUnlocked   bool   `json:"is_unlocked"`
+	IsCheckpoint bool   `json:"is_checkpoint"`
+	UnlockScore  *int32 `json:"unlock_score"`
+}
+
+func NewLesson(l db.Lesson) Lesson {
+	return Lesson{
+		LessonID:     uuidString(l.LessonID),
+		CourseID:     uuidString(l.CourseID),
+		LessonTitle:  l.LessonTitle,
+		LessonOrder:  l.LessonOrder,
+		XpReward:     l.XpReward.Int32,
+		IsUnlocked:   l.IsUnlocked.Bool,
+		IsCheckpoint: l.IsCheckpoint,
+		UnlockScore:  nullableInt32(l.UnlockScore),
+	}
+}
+
+func NewLessons(rows []db.Lesson) []Lesson {
+	lessons := make([]Lesson, 0, len(rows))
+	for _, l := range rows {
+		lessons = append(lessons, NewLesson(l))
+	}
+	return lessons
+}
+
+func NewCourseLessons(rows []db.GetLessonsByCourseIdRow) []Lesson {
+	lessons := make([]Lesson, 0, len(rows))
+	for _, l := range rows {
+		lessons = append(lessons, Lesson{
+			LessonID:     uuidString(l.LessonID),
+			CourseID:     uuidString(l.CourseID),
+			LessonTitle:  l.LessonTitle,
+			LessonOrder:  l.LessonOrder,
+			XpReward:     l.XpReward.Int32,
+			IsUnlocked:   l.IsUnlocked.Bool,
+			IsCheckpoint: l.IsCheckpoint,
+			UnlockScore:  nullableInt32(l.UnlockScore),
+		})
+	}
+	return lessons
+}
+
+// Exercise includes the correct answer, so it is only returned to admins
+type Exercise struct {
+	ExerciseID    string          `json:"exercise_id"`
+	LessonID      string          `json:"lesson_id"`
+	ExerciseType  *string         `json:"exercise_type"`
+	QuestionText  string          `json:"question_text"`
+	CorrectAnswer string          `json:"correct_answer"`
+	Options       json.RawMessage `json:"options"`
+	AudioUrl      *string         `json:"audio_url"`
+}
+
+func NewExercise(e db.Exercise) Exercise {
+	var options json.RawMessage
+	if len(e.Options) > 0 {
+		options = json.RawMessage(e.Options)
+	}
+	return Exercise{
+		ExerciseID:    uuidString(e.ExerciseID),
+		LessonID:      uuidString(e.LessonID),
+		ExerciseType:  nullableString(e.ExerciseType),
+		QuestionText:  e.QuestionText,
+		CorrectAnswer: e.CorrectAnswer,
+		Options:       options,
+		AudioUrl:      nullableString(e.AudioUrl),
+	}
+}
+
+func NewExercises(rows []db.Exercise) []Exercise {
+	exercises := make([]Exercise, 0, len(rows))
+	for _, e := range rows {
+		exercises = append(exercises, NewExercise(e))
+	}
+	return exercises
+}
+
+// choices keeps only the choices of an exercise's options. The rest holds
+// grading settings such as alternative accepted answers.
+func choices(options []byte) json.RawMessage {
+	var parsed struct {
+		Options json.RawMessage `json:"options"`
+	}
+	if len(options) == 0 || json.Unmarshal(options, &parsed) != nil || len(parsed.Options) == 0 {
+		return nil
+	}
+	out, err := json.Marshal(parsed)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// LearnerExercise is an exercise as shown to learners, without its correct answer
+type LearnerExercise struct {
+	ExerciseID   string          `json:"exercise_id"`
+	LessonID     string          `json:"lesson_id"`
+	ExerciseType *string         `json:"exercise_type"`
+	QuestionText string          `json:"question_text"`
+	Options      json.RawMessage `json:"options"`
+	AudioUrl     *string         `json:"audio_url"`
+}
+
+func NewLearnerExercises(rows []db.ListLessonExercisesRow) []LearnerExercise {
+	exercises := make([]LearnerExercise, 0, len(rows))
+	for _, e := range rows {
+		exercises = append(exercises, LearnerExercise{
+			ExerciseID:   uuidString(e.ExerciseID),
+			LessonID:     uuidString(e.LessonID),
+			ExerciseType: nullableString(e.ExerciseType),
+			QuestionText: e.QuestionText,
+			Options:      choices(e.Options),
+			AudioUrl:     nullableString(e.AudioUrl),
+		})
+	}
+	return exercises
+}
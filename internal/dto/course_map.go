@@ -0,0 +1,38 @@
+package dto
+
+import (
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/unlock"
+)
+
+// CourseMapLesson is a lesson on a learner's course map
+type CourseMapLesson struct {
+	LessonID           string `json:"lesson_id"`
+	LessonTitle        string `json:"lesson_title"`
+	LessonOrder        int32  `json:"lesson_order"`
+	XpReward           int32  `json:"xp_reward"`
+	IsCheckpoint       bool   `json:"is_checkpoint"`
+	Status             string `json:"status"`
+	Score              int32  `json:"score"`
+	CompletedExercises int64  `json:"completed_exercises"`
+	TotalExercises     int64  `json:"total_exercises"`
+}
+
+// NewCourseMap pairs each lesson with the learner's progress and status on it
+func NewCourseMap(rows []db.ListCourseLessonProgressRow, progress []unlock.Lesson, statuses []string) []CourseMapLesson {
+	lessons := make([]CourseMapLesson, 0, len(rows))
+	for i, l := range rows {
+		lessons = append(lessons, CourseMapLesson{
+			LessonID:           uuidString(l.LessonID),
+			LessonTitle:        l.LessonTitle,
+			LessonOrder:        l.LessonOrder,
+			XpReward:           l.XpReward.Int32,
+			IsCheckpoint:       l.IsCheckpoint,
+			Status:             statuses[i],
+			Score:              progress[i].Score(),
+			CompletedExercises: l.CompletedExercises,
+			TotalExercises:     l.TotalExercises,
+		})
+	}
+	return lessons
+}
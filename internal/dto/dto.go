@@ -0,0 +1,42 @@
+// Package dto defines the JSON shapes returned by the API.
+// Handlers convert sqlc models to these types instead of serializing them directly,
+// so password hashes and other secrets never reach a response and nullable
+// columns render as plain values or null.
+package dto
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// uuidString formats a UUID, returning an empty string for NULL
+func uuidString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.String()
+}
+
+func nullableString(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+func nullableInt32(i pgtype.Int4) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int32
+}
+
+// timestamp formats a timestamp as RFC 3339 in UTC, returning nil for NULL
+func timestamp(ts pgtype.Timestamp) *string {
+	if !ts.Valid {
+		return nil
+	}
+	s := ts.Time.UTC().Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	db "lingo/internal/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func marshalToMap(t *testing.T, v any) map[string]any {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(data, &m))
+	return m
+}
+
+func TestNewAdminOmitsSecrets(t *testing.T) {
+	id := uuid.New()
+	joinedAt := time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	admin := NewAdmin(db.Admin{
+		AdminID:       pgtype.UUID{Bytes: id, Valid: true},
+		FirstName:     "Ada",
+		LastName:      "Lovelace",
+		Email:         "ada@example.com",
+		Password:      "$2a$10$hash",
+		JoinedAt:      pgtype.Timestamp{Time: joinedAt, Valid: true},
+		Role:          "super_admin",
+		TotpSecret:    pgtype.Text{String: "JBSWY3DPEHPK3PXP", Valid: true},
+		TotpEnabledAt: pgtype.Timestamp{Time: joinedAt, Valid: true},
+		TotpLastStep:  pgtype.Int8{Int64: 42, Valid: true},
+	})
+	m := marshalToMap(t, admin)
+
+	for _, key := range []string{"password", "totp_secret", "totp_last_step", "verification_sent_at"} {
+		require.NotContains(t, m, key)
+	}
+	require.Equal(t, id.String(), m["admin_id"])
+	require.Equal(t, "2025-03-01T12:30:00Z", m["joined_at"])
+	require.Nil(t, m["profile_image_url"])
+	require.Nil(t, m["email_verified_at"])
+	require.Equal(t, true, m["two_factor_enabled"])
+}
+
+func TestNewExercise(t *testing.T) {
+	testCases := []struct {
+		name        string
+		options     []byte
+		audioUrl    pgtype.Text
+		wantOptions any
+		wantAudio   any
+	}{
+		{
+			name:        "WithOptions",
+			options:     []byte(`["hola","adiós"]`),
+			audioUrl:    pgtype.Text{String: "https://cdn.example.com/hola.mp3", Valid: true},
+			wantOptions: []any{"hola", "adiós"},
+			wantAudio:   "https://cdn.example.com/hola.mp3",
+		},
+		{
+			name: "NullColumns",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := marshalToMap(t, NewExercise(db.Exercise{
+				ExerciseID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				LessonID:     pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				QuestionText: "Translate hello",
+				Options:      tc.options,
+				AudioUrl:     tc.audioUrl,
+			}))
+			require.Equal(t, tc.wantOptions, m["options"])
+			require.Equal(t, tc.wantAudio, m["audio_url"])
+			require.Nil(t, m["exercise_type"])
+		})
+	}
+}
+
+func TestNewLearnerExercisesHidesGradingSettings(t *testing.T) {
+	exercises := NewLearnerExercises([]db.ListLessonExercisesRow{
+		{
+			ExerciseID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			ExerciseType: pgtype.Text{String: "MultipleChoice", Valid: true},
+			Options:      []byte(`{"options": ["Sannu", "Nagode"]}`),
+		},
+		{
+			ExerciseID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			ExerciseType: pgtype.Text{String: "FillBlank", Valid: true},
+			Options:      []byte(`{"accepted_answers": ["ẹ n lẹ"], "max_typos": 0}`),
+		},
+	})
+	require.Len(t, exercises, 2)
+
+	choice := marshalToMap(t, exercises[0])
+	require.Equal(t, map[string]any{"options": []any{"Sannu", "Nagode"}}, choice["options"])
+	require.NotContains(t, choice, "correct_answer")
+
+	fillBlank := marshalToMap(t, exercises[1])
+	require.Nil(t, fillBlank["options"])
+}
+
+func TestNewPublicLearnerUsesCurrentStreak(t *testing.T) {
+	learner := NewPublicLearner(db.GetUserByIdRow{
+		UserID:      pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:    "ada",
+		Email:       "ada@example.com",
+		StreakCount: pgtype.Int4{Int32: 12, Valid: true},
+	}, 0)
+	m := marshalToMap(t, learner)
+
+	require.Equal(t, float64(0), m["streak_count"])
+	require.NotContains(t, m, "email")
+}
+
+func TestNewLeague(t *testing.T) {
+	weekStart := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	standings := make([]db.ListLeagueStandingsRow, 10)
+	for i := range standings {
+		standings[i] = db.ListLeagueStandingsRow{
+			UserID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Username: "learner",
+			Xp:       int32(100 - i),
+		}
+	}
+	// A settled league keeps the outcome it was settled with
+	standings[0].Outcome = pgtype.Text{String: "stayed", Valid: true}
+
+	league := NewLeague(db.League{
+		LeagueID:  pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Tier:      1,
+		WeekStart: pgtype.Date{Time: weekStart, Valid: true},
+	}, standings)
+
+	require.Equal(t, "Silver", league.TierName)
+	require.Equal(t, "2025-03-10", league.WeekStart)
+	require.Equal(t, "2025-03-17T00:00:00Z", league.EndsAt)
+	require.Len(t, league.Members, 10)
+	require.Equal(t, 1, league.Members[0].Rank)
+	require.Equal(t, "stayed", league.Members[0].Outcome)
+	// A ten learner league promotes its top two and demotes its last one
+	require.Equal(t, "promoted", league.Members[1].Outcome)
+	require.Equal(t, "stayed", league.Members[2].Outcome)
+	require.Equal(t, "stayed", league.Members[8].Outcome)
+	require.Equal(t, "demoted", league.Members[9].Outcome)
+}
@@ -0,0 +1,132 @@
+package dto
+
+import (
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/league"
+	"time"
+)
+
+// LeaderboardEntry is a learner's rank on a leaderboard
+type LeaderboardEntry struct {
+	Rank            int64   `json:"rank"`
+	UserID          string  `json:"user_id"`
+	Username        string  `json:"username"`
+	ProfileImageUrl *string `json:"profile_image_url"`
+	XP              int64   `json:"xp"`
+}
+
+func NewGlobalLeaderboardEntry(e db.GlobalLeaderboard) LeaderboardEntry {
+	return LeaderboardEntry{
+		Rank:            e.Rank,
+		UserID:          uuidString(e.UserID),
+		Username:        e.Username,
+		ProfileImageUrl: nullableString(e.ProfileImageUrl),
+		XP:              int64(e.Xp),
+	}
+}
+
+func NewGlobalLeaderboard(rows []db.GlobalLeaderboard) []LeaderboardEntry {
+	entries := make([]LeaderboardEntry, 0, len(rows))
+	for _, e := range rows {
+		entries = append(entries, NewGlobalLeaderboardEntry(e))
+	}
+	return entries
+}
+
+func NewLanguageLeaderboardEntry(e db.LanguageLeaderboard) LeaderboardEntry {
+	return LeaderboardEntry{
+		Rank:            e.Rank,
+		UserID:          uuidString(e.UserID),
+		Username:        e.Username,
+		ProfileImageUrl: nullableString(e.ProfileImageUrl),
+		XP:              e.Xp,
+	}
+}
+
+func NewLanguageLeaderboard(rows []db.LanguageLeaderboard) []LeaderboardEntry {
+	entries := make([]LeaderboardEntry, 0, len(rows))
+	for _, e := range rows {
+		entries = append(entries, NewLanguageLeaderboardEntry(e))
+	}
+	return entries
+}
+
+func NewFriendsLeaderboard(rows []db.ListFriendsLeaderboardRow) []LeaderboardEntry {
+	entries := make([]LeaderboardEntry, 0, len(rows))
+	for _, e := range rows {
+		entries = append(entries, LeaderboardEntry{
+			Rank:            e.Rank,
+			UserID:          uuidString(e.UserID),
+			Username:        e.Username,
+			ProfileImageUrl: nullableString(e.ProfileImageUrl),
+			XP:              int64(e.Xp),
+		})
+	}
+	return entries
+}
+
+// League is a weekly league and its current standings
+type League struct {
+	LeagueID  string         `json:"league_id"`
+	Tier      int32          `json:"tier"`
+	TierName  string         `json:"tier_name"`
+	WeekStart string         `json:"week_start"`
+	EndsAt    string         `json:"ends_at"`
+	Members   []LeagueMember `json:"members"`
+}
+
+// LeagueMember is a learner's standing in a league. Until the league is
+// settled, Outcome is what their current rank would do at the end of the week.
+type LeagueMember struct {
+	Rank            int     `json:"rank"`
+	UserID          string  `json:"user_id"`
+	Username        string  `json:"username"`
+	ProfileImageUrl *string `json:"profile_image_url"`
+	XP              int32   `json:"xp"`
+	Outcome         string  `json:"outcome"`
+}
+
+func NewLeague(l db.League, standings []db.ListLeagueStandingsRow) League {
+	members := make([]LeagueMember, 0, len(standings))
+	for i, m := range standings {
+		outcome := m.Outcome.String
+		if !m.Outcome.Valid {
+			outcome = league.Outcome(l.Tier, i+1, len(standings))
+		}
+		members = append(members, LeagueMember{
+			Rank:            i + 1,
+			UserID:          uuidString(m.UserID),
+			Username:        m.Username,
+			ProfileImageUrl: nullableString(m.ProfileImageUrl),
+			XP:              m.Xp,
+			Outcome:         outcome,
+		})
+	}
+	return League{
+		LeagueID:  uuidString(l.LeagueID),
+		Tier:      l.Tier,
+		TierName:  league.TierName(l.Tier),
+		WeekStart: l.WeekStart.Time.Format(dateLayout),
+		EndsAt:    l.WeekStart.Time.AddDate(0, 0, 7).UTC().Format(time.RFC3339),
+		Members:   members,
+	}
+}
+
+// LeagueResult is how a learner finished a settled league
+type LeagueResult struct {
+	Tier      int32  `json:"tier"`
+	TierName  string `json:"tier_name"`
+	WeekStart string `json:"week_start"`
+	Rank      int32  `json:"rank"`
+	Outcome   string `json:"outcome"`
+}
+
+func NewLeagueResult(r db.GetUserLeagueResultRow) LeagueResult {
+	return LeagueResult{
+		Tier:      r.Tier,
+		TierName:  league.TierName(r.Tier),
+		WeekStart: r.WeekStart.Time.Format(dateLayout),
+		Rank:      r.FinalRank.Int32,
+		Outcome:   r.Outcome.String,
+	}
+}
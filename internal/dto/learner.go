@@ -0,0 +1,113 @@
+package dto
+
+import db "lingo/internal/db/sqlc"
+
+// Learner is a learner's own account, including their email
+type Learner struct {
+	UserID          string  `json:"user_id"`
+	Username        string  `json:"username"`
+	Email           string  `json:"email"`
+	ProfileImageUrl *string `json:"profile_image_url"`
+	StreakCount     int32   `json:"streak_count"`
+	XpPoints        int32   `json:"xp_points"`
+	LastActiveDate  *string `json:"last_active_date"`
+	JoinedAt        *string `json:"joined_at"`
+	EmailVerifiedAt *string `json:"email_verified_at"`
+}
+
+// NewLearner converts a learner's account. currentStreak is their streak as of
+// today, since the stored streak_count can be stale.
+func NewLearner(u db.GetUserByIdRow, currentStreak int32) Learner {
+	return Learner{
+		UserID:          uuidString(u.UserID),
+		Username:        u.Username,
+		Email:           u.Email,
+		ProfileImageUrl: nullableString(u.ProfileImageUrl),
+		StreakCount:     currentStreak,
+		XpPoints:        u.XpPoints.Int32,
+		LastActiveDate:  timestamp(u.LastActiveDate),
+		JoinedAt:        timestamp(u.JoinedAt),
+		EmailVerifiedAt: timestamp(u.EmailVerifiedAt),
+	}
+}
+
+// NewRegisteredLearner converts a freshly created learner, whose email isn't verified yet
+func NewRegisteredLearner(u db.CreateUserRow) Learner {
+	return Learner{
+		UserID:          uuidString(u.UserID),
+		Username:        u.Username,
+		Email:           u.Email,
+		ProfileImageUrl: nullableString(u.ProfileImageUrl),
+		StreakCount:     u.StreakCount.Int32,
+		XpPoints:        u.XpPoints.Int32,
+		LastActiveDate:  timestamp(u.LastActiveDate),
+		JoinedAt:        timestamp(u.JoinedAt),
+	}
+}
+
+// PublicLearner is what other learners can see of a learner
+type PublicLearner struct {
+	UserID          string  `json:"user_id"`
+	Username        string  `json:"username"`
+	ProfileImageUrl *string `json:"profile_image_url"`
+	StreakCount     int32   `json:"streak_count"`
+	XpPoints        int32   `json:"xp_points"`
+	LastActiveDate  *string `json:"last_active_date"`
+	JoinedAt        *string `json:"joined_at"`
+}
+
+func NewPublicLearner(u db.GetUserByIdRow, currentStreak int32) PublicLearner {
+	return PublicLearner{
+		UserID:          uuidString(u.UserID),
+		Username:        u.Username,
+		ProfileImageUrl: nullableString(u.ProfileImageUrl),
+		StreakCount:     currentStreak,
+		XpPoints:        u.XpPoints.Int32,
+		LastActiveDate:  timestamp(u.LastActiveDate),
+		JoinedAt:        timestamp(u.JoinedAt),
+	}
+}
+
+// EnrolledCourse is a course a learner is enrolled in, with their progress
+type EnrolledCourse struct {
+	CourseID             string  `json:"course_id"`
+	CourseName           string  `json:"course_name"`
+	DifficultyLevel      *string `json:"difficulty_level"`
+	LanguageName         string  `json:"language_name"`
+	LanguageCode         string  `json:"language_code"`
+	FlagEmoji            *string `json:"flag_emoji"`
+	EnrollmentDate       *string `json:"enrollment_date"`
+	CompletionPercentage float64 `json:"completion_percentage"`
+}
+
+func NewEnrolledCourses(rows []db.ListUserCoursesRow) []EnrolledCourse {
+	courses := make([]EnrolledCourse, 0, len(rows))
+	for _, c := range rows {
+		courses = append(courses, EnrolledCourse{
+			CourseID:             uuidString(c.CourseID),
+			CourseName:           c.CourseName,
+			DifficultyLevel:      nullableString(c.DifficultyLevel),
+			LanguageName:         c.LanguageName,
+			LanguageCode:         c.LanguageCode,
+			FlagEmoji:            nullableString(c.FlagEmoji),
+			EnrollmentDate:       timestamp(c.EnrollmentDate),
+			CompletionPercentage: c.CompletionPercentage.Float64,
+		})
+	}
+	return courses
+}
+
+// Enrollment is a learner's enrollment in a single course
+type Enrollment struct {
+	CourseID             string  `json:"course_id"`
+	EnrollmentDate       *string `json:"enrollment_date"`
+	CompletionPercentage float64 `json:"completion_percentage"`
+}
+
+func NewEnrollment(uc db.UserCourse) Enrollment {
+	return Enrollment{
+		CourseID:             uuidString(uc.CourseID),
+		EnrollmentDate:       timestamp(uc.EnrollmentDate),
+		CompletionPercentage: uc.CompletionPercentage.Float64,
+	}
+}
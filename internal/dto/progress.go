@@ -0,0 +1,36 @@
+package dto
+
+import (
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/grading"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Submission is the graded result of an answer. The correct answer is only
+// revealed here, after the learner has answered.
+type Submission struct {
+	ExerciseID       string  `json:"exercise_id"`
+	Score            int     `json:"score"`
+	Correct          bool    `json:"correct"`
+	Feedback         string  `json:"feedback"`
+	CorrectAnswer    string  `json:"correct_answer"`
+	BestScore        int32   `json:"best_score"`
+	IsCompleted      bool    `json:"is_completed"`
+	CompletedAt      *string `json:"completed_at"`
+	CourseCompletion float64 `json:"course_completion_percentage"`
+}
+
+func NewSubmission(result grading.Result, correctAnswer string, progress db.UserProgress, completion pgtype.Float8) Submission {
+	return Submission{
+		ExerciseID:       uuidString(progress.ExerciseID),
+		Score:            result.Score,
+		Correct:          result.Correct,
+		Feedback:         result.Feedback,
+		CorrectAnswer:    correctAnswer,
+		BestScore:        progress.Score.Int32,
+		IsCompleted:      progress.IsCompleted.Bool,
+		CompletedAt:      timestamp(progress.CompletedAt),
+		CourseCompletion: completion.Float64,
+	}
+}
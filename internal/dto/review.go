@@ -0,0 +1,46 @@
+package dto
+
+import db "lingo/internal/db/sqlc"
+
+// Review is when an exercise is next due for review
+type Review struct {
+	DueAt        *string `json:"due_at"`
+	IntervalDays int32   `json:"interval_days"`
+	Repetitions  int32   `json:"repetitions"`
+}
+
+func NewReview(r db.ReviewItem) Review {
+	return Review{
+		DueAt:        timestamp(r.DueAt),
+		IntervalDays: r.IntervalDays,
+		Repetitions:  r.Repetitions,
+	}
+}
+
+// ReviewExercise is a due exercise in a review session, without its correct answer
+type ReviewExercise struct {
+	LearnerExercise
+	CourseID     string  `json:"course_id"`
+	DueAt        *string `json:"due_at"`
+	IntervalDays int32   `json:"interval_days"`
+}
+
+func NewReviewExercises(rows []db.ListDueReviewsRow) []ReviewExercise {
+	exercises := make([]ReviewExercise, 0, len(rows))
+	for _, e := range rows {
+		exercises = append(exercises, ReviewExercise{
+			LearnerExercise: LearnerExercise{
+				ExerciseID:   uuidString(e.ExerciseID),
+				LessonID:     uuidString(e.LessonID),
+				ExerciseType: nullableString(e.ExerciseType),
+				QuestionText: e.QuestionText,
+				Options:      choices(e.Options),
+				AudioUrl:     nullableString(e.AudioUrl),
+			},
+			CourseID:     uuidString(e.CourseID),
+			DueAt:        timestamp(e.DueAt),
+			IntervalDays: e.IntervalDays,
+		})
+	}
+	return exercises
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/streak"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Streak is a learner's daily streak as of their current local day
+type Streak struct {
+	CurrentStreak  int32   `json:"current_streak"`
+	LongestStreak  int32   `json:"longest_streak"`
+	StreakFreezes  int32   `json:"streak_freezes"`
+	Timezone       string  `json:"timezone"`
+	LastStreakDate *string `json:"last_streak_date"`
+	ExtendedToday  bool    `json:"extended_today"`
+}
+
+func NewStreak(s streak.State, timezone string, today time.Time) Streak {
+	var last *string
+	if !s.LastDay.IsZero() {
+		formatted := s.LastDay.Format(dateLayout)
+		last = &formatted
+	}
+	return Streak{
+		CurrentStreak:  streak.Current(s, today),
+		LongestStreak:  s.Longest,
+		StreakFreezes:  s.Freezes,
+		Timezone:       timezone,
+		LastStreakDate: last,
+		ExtendedToday:  s.LastDay.Equal(today),
+	}
+}
+
+// StreakDay is a day on the streak calendar, either active or covered by a streak freeze
+type StreakDay struct {
+	Date   string `json:"date"`
+	Status string `json:"status"`
+}
+
+func NewStreakDays(rows []db.ListStreakDaysRow) []StreakDay {
+	days := make([]StreakDay, 0, len(rows))
+	for _, d := range rows {
+		days = append(days, StreakDay{
+			Date:   d.StreakDate.Time.Format(dateLayout),
+			Status: d.Status,
+		})
+	}
+	return days
+}
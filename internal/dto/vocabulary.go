@@ -0,0 +1,67 @@
+package dto
+
+import db "lingo/internal/db/sqlc"
+
+// Vocabulary is a word or phrase taught in a language
+type Vocabulary struct {
+	VocabularyID    string  `json:"vocabulary_id"`
+	LanguageID      string  `json:"language_id"`
+	Word            string  `json:"word"`
+	Translation     string  `json:"translation"`
+	PartOfSpeech    *string `json:"part_of_speech"`
+	ToneMarked      *string `json:"tone_marked"`
+	AudioUrl        *string `json:"audio_url"`
+	ExampleSentence *string `json:"example_sentence"`
+	CreatedAt       *string `json:"created_at"`
+}
+
+func NewVocabulary(v db.Vocabulary) Vocabulary {
+	return Vocabulary{
+		VocabularyID:    uuidString(v.VocabularyID),
+		LanguageID:      uuidString(v.LanguageID),
+		Word:            v.Word,
+		Translation:     v.Translation,
+		PartOfSpeech:    nullableString(v.PartOfSpeech),
+		ToneMarked:      nullableString(v.ToneMarked),
+		AudioUrl:        nullableString(v.AudioUrl),
+		ExampleSentence: nullableString(v.ExampleSentence),
+		CreatedAt:       timestamp(v.CreatedAt),
+	}
+}
+
+func NewVocabularyList(rows []db.Vocabulary) []Vocabulary {
+	words := make([]Vocabulary, 0, len(rows))
+	for _, v := range rows {
+		words = append(words, NewVocabulary(v))
+	}
+	return words
+}
+
+// LearnedWord is a word the learner has met in a completed exercise
+type LearnedWord struct {
+	VocabularyID    string  `json:"vocabulary_id"`
+	Word            string  `json:"word"`
+	Translation     string  `json:"translation"`
+	PartOfSpeech    *string `json:"part_of_speech"`
+	ToneMarked      *string `json:"tone_marked"`
+	AudioUrl        *string `json:"audio_url"`
+	ExampleSentence *string `json:"example_sentence"`
+	LearnedAt       *string `json:"learned_at"`
+}
+
+func NewLearnedWords(rows []db.ListLearnedVocabularyRow) []LearnedWord {
+	words := make([]LearnedWord, 0, len(rows))
+	for _, v := range rows {
+		words = append(words, LearnedWord{
+			VocabularyID:    uuidString(v.VocabularyID),
+			Word:            v.Word,
+			Translation:     v.Translation,
+			PartOfSpeech:    nullableString(v.PartOfSpeech),
+			ToneMarked:      nullableString(v.ToneMarked),
+			AudioUrl:        nullableString(v.AudioUrl),
+			ExampleSentence: nullableString(v.ExampleSentence),
+			LearnedAt:       timestamp(v.LearnedAt),
+		})
+	}
+	return words
+}
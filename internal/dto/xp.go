@@ -0,0 +1,50 @@
+package dto
+
+import (
+	db "lingo/internal/db/sqlc"
+	"time"
+)
+
+// XPAward is XP newly awarded to a learner
+type XPAward struct {
+	Source string `json:"source"`
+	Amount int32  `json:"amount"`
+}
+
+func NewXPAwards(entries []db.XpLedger) []XPAward {
+	awards := make([]XPAward, 0, len(entries))
+	for _, e := range entries {
+		awards = append(awards, XPAward{
+			Source: e.Source,
+			Amount: e.Amount,
+		})
+	}
+	return awards
+}
+
+// XPPeriod is the XP a learner earned over a day or week
+type XPPeriod struct {
+	PeriodStart string `json:"period_start"`
+	XP          int64  `json:"xp"`
+	Awards      int64  `json:"awards"`
+}
+
+// NewXPHistory lists every period in starts, with zero XP for periods without awards
+func NewXPHistory(starts []time.Time, rows []db.ListXPHistoryRow) []XPPeriod {
+	byStart := make(map[string]db.ListXPHistoryRow, len(rows))
+	for _, r := range rows {
+		byStart[r.PeriodStart.Time.Format(dateLayout)] = r
+	}
+
+	history := make([]XPPeriod, 0, len(starts))
+	for _, start := range starts {
+		key := start.Format(dateLayout)
+		r := byStart[key]
+		history = append(history, XPPeriod{
+			PeriodStart: key,
+			XP:          r.Xp,
+			Awards:      r.Awards,
+		})
+	}
+	return history
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/internal/middleware"
+	"lingo/pkg/auth/rbac"
 	"lingo/pkg/auth/tokengen"
 	"lingo/utils"
 	"log"
@@ -13,58 +17,131 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type AdminHandler struct {
-	store *db.SQLStore
-	tok   tokengen.Maker
+	store     *db.SQLStore
+	tok       tokengen.Maker
+	mailer    utils.EmailSender
+	config    utils.Config
+	policy    *utils.PasswordPolicy
+	twoFactor rbac.TwoFactorPolicy
 }
 
-func NewAdminHandler(store *db.SQLStore, tok tokengen.Maker) *AdminHandler {
+func NewAdminHandler(store *db.SQLStore, tok tokengen.Maker, mailer utils.EmailSender, config utils.Config, policy *utils.PasswordPolicy, twoFactor rbac.TwoFactorPolicy) *AdminHandler {
 	return &AdminHandler{
-		store: store,
-		tok:   tok,
+		store:     store,
+		tok:       tok,
+		mailer:    mailer,
+		config:    config,
+		policy:    policy,
+		twoFactor: twoFactor,
 	}
 }
 
+type RegisterAdminRequest struct {
+	InviteToken     string `json:"invite_token" binding:"required"`
+	FirstName       string `json:"first_name" binding:"required,max=50"`
+	LastName        string `json:"last_name" binding:"required,max=50"`
+	Password        string `json:"password" binding:"required"`
+	ProfileImageUrl string `json:"profile_image_url" binding:"omitempty,url,max=255"`
+}
+
+// RegisterAdmin creates an admin account from a valid, unused invite.
+// The email and role come from the invite rather than the request.
 func (h *AdminHandler) RegisterAdmin(c *gin.Context) {
-	var req db.CreateAdminParams
-	// Removed unused variable newAdmin
+	var req RegisterAdminRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	// Transaction to create an admin
-	err := h.store.ExecTx(c, func(q db.Querier) error {
-		// Check if the admin already exists
-		_, err := q.GetAdminByEmail(c, req.Email)
+
+	if err := h.policy.Validate(req.Password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Hash password
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process password"})
+		return
+	}
+
+	// Transaction to redeem the invite and create the admin
+	var admin db.Admin
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		invite, err := q.GetAdminInviteForUpdate(c, utils.HashToken(req.InviteToken))
 		if err != nil {
-			if err != pgx.ErrNoRows {
-				return err
-			}
-			// Hash password
-			hashedPassword, err := utils.HashPassword(req.Password)
-			if err != nil {
-				return err
-			}
-			req.Password = hashedPassword
-			// Create the admin
-			admin, err := h.store.CreateAdmin(c, req)
-			fmt.Print(admin)
-			if err != nil {
-				return err
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errInvalidInvite
 			}
+			return err
+		}
+		if invite.UsedAt.Valid || time.Now().UTC().After(invite.ExpiresAt.Time) {
+			return errInvalidInvite
+		}
+
+		// Check if the admin already exists
+		_, err = q.GetAdminByEmail(c, invite.Email)
+		if err == nil {
+			return errAdminExists
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		admin, err = q.CreateAdmin(c, db.CreateAdminParams{
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+			Email:     invite.Email,
+			Password:  hashedPassword,
+			ProfileImageUrl: pgtype.Text{
+				String: req.ProfileImageUrl,
+				Valid:  req.ProfileImageUrl != "",
+			},
+			Role: invite.Role,
+		})
+		if err != nil {
+			return err
 		}
 
+		// The invite was delivered to this address, which proves the admin owns it
+		admin.EmailVerifiedAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+		_, err = q.MarkAdminEmailVerified(c, db.MarkAdminEmailVerifiedParams{
+			EmailVerifiedAt: admin.EmailVerifiedAt,
+			AdminID:         admin.AdminID,
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.MarkAdminInviteUsed(c, invite.InviteID)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return errInvalidInvite
+		}
 		return nil
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, errInvalidInvite):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errAdminExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create admin"})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Admin created successfully",
+		"message":  "Admin created successfully",
+		"admin_id": admin.AdminID.String(),
+		"admin":    dto.NewAdmin(admin),
 	})
 }
 
@@ -80,10 +157,20 @@ func (h *AdminHandler) LoginAdmin(c *gin.Context) {
 		return
 	}
 
+	wait, err := checkLoginThrottle(c, h.store, tokengen.RoleAdmin, req.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+	if wait > 0 {
+		respondWithLoginThrottle(c, wait)
+		return
+	}
+
 	admin, err := h.store.GetAdminForLogin(c, req.Email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+			failLogin(c, h.store, h.mailer, h.config, tokengen.RoleAdmin, req.Email, h.adminContact, "/admin/forgot-password")
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
@@ -91,19 +178,34 @@ func (h *AdminHandler) LoginAdmin(c *gin.Context) {
 	}
 
 	if !utils.CompareHashAndPassword(admin.Password, req.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+		failLogin(c, h.store, h.mailer, h.config, tokengen.RoleAdmin, req.Email, h.adminContact, "/admin/forgot-password")
+		return
+	}
+
+	if h.config.RequireEmailVerification && !admin.EmailVerifiedAt.Valid {
+		c.JSON(http.StatusForbidden, gin.H{"error": errEmailNotVerified.Error()})
 		return
 	}
 
-	token, err := h.tok.CreateToken(admin.AdminID, 30*time.Minute)
+	totpEnabled, err := h.store.IsAdminTotpEnabled(c, admin.AdminID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+	// With two-factor enabled, failures are only cleared once the code is verified.
+	// Clearing them here would let anyone with the password reset the lockout
+	// between bursts of guessed codes.
+	if totpEnabled {
+		h.startTwoFactorChallenge(c, admin.AdminID)
 		return
 	}
+	if err := clearLoginFailures(c, h.store, tokengen.RoleAdmin, req.Email); err != nil {
+		log.Printf("failed to clear failed logins: %v", err)
+	}
 
-	refreshToken, err := h.tok.CreateToken(admin.AdminID, 7*24*time.Hour)
+	token, refreshToken, err := startSession(c, h.store, h.tok, admin.AdminID, tokengen.RoleAdmin)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create refresh token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create session"})
 		return
 	}
 
@@ -114,6 +216,73 @@ func (h *AdminHandler) LoginAdmin(c *gin.Context) {
 	})
 }
 
+// RefreshAdminToken rotates an admin's refresh token and issues a new token pair
+func (h *AdminHandler) RefreshAdminToken(c *gin.Context) {
+	refreshSession(c, h.store, h.tok, tokengen.RoleAdmin)
+}
+
+// LogoutAdmin revokes the session of the presented refresh token
+func (h *AdminHandler) LogoutAdmin(c *gin.Context) {
+	logoutSession(c, h.store, h.tok, tokengen.RoleAdmin)
+}
+
+// LogoutAllAdminDevices revokes every session of the authenticated admin
+func (h *AdminHandler) LogoutAllAdminDevices(c *gin.Context) {
+	logoutAllSessions(c, h.store, tokengen.RoleAdmin)
+}
+
+// adminContact looks up the admin that emails for the given address should go to
+func (h *AdminHandler) adminContact(ctx context.Context, email string) (accountContact, error) {
+	admin, err := h.store.GetAdminByEmail(ctx, email)
+	if err != nil {
+		return accountContact{}, err
+	}
+	return accountContact{ID: admin.AdminID, Name: admin.FirstName, Email: admin.Email}, nil
+}
+
+func reserveAdminVerification(ctx context.Context, q db.Querier, ownerID pgtype.UUID, sentAt, resendBefore pgtype.Timestamp) (int64, error) {
+	return q.ReserveAdminVerificationEmail(ctx, db.ReserveAdminVerificationEmailParams{
+		SentAt:       sentAt,
+		AdminID:      ownerID,
+		ResendBefore: resendBefore,
+	})
+}
+
+// VerifyAdminEmail marks an admin's email as verified using the emailed link
+func (h *AdminHandler) VerifyAdminEmail(c *gin.Context) {
+	loadEmail := func(ctx context.Context, ownerID pgtype.UUID) (string, error) {
+		admin, err := h.store.GetAdminById(ctx, ownerID)
+		return admin.Email, err
+	}
+	markVerified := func(ctx context.Context, ownerID pgtype.UUID, verifiedAt pgtype.Timestamp) (int64, error) {
+		return h.store.MarkAdminEmailVerified(ctx, db.MarkAdminEmailVerifiedParams{
+			EmailVerifiedAt: verifiedAt,
+			AdminID:         ownerID,
+		})
+	}
+	verifyEmail(c, h.tok, tokengen.RoleAdmin, loadEmail, markVerified)
+}
+
+// ResendAdminVerification emails a new verification link to an unverified admin
+func (h *AdminHandler) ResendAdminVerification(c *gin.Context) {
+	resendVerification(c, h.store, h.tok, h.mailer, h.config, tokengen.RoleAdmin, h.adminContact, reserveAdminVerification, "/admin/verify-email")
+}
+
+// ForgotAdminPassword emails a password reset code to an admin
+func (h *AdminHandler) ForgotAdminPassword(c *gin.Context) {
+	requestPasswordReset(c, h.store, h.mailer, h.config, tokengen.RoleAdmin, h.adminContact, "/admin/reset-password")
+}
+
+// ResetAdminPassword sets an admin's new password using an emailed reset code
+func (h *AdminHandler) ResetAdminPassword(c *gin.Context) {
+	resetPassword(c, h.store, h.policy, tokengen.RoleAdmin, func(ctx context.Context, q db.Querier, ownerID pgtype.UUID, hashedPassword string) error {
+		return q.UpdateAdminPassword(ctx, db.UpdateAdminPasswordParams{
+			Password: hashedPassword,
+			AdminID:  ownerID,
+		})
+	})
+}
+
 // CreateNewLanguage creates a new language in the database
 func (h *AdminHandler) CreateNewLanguage(c *gin.Context) {
 	var req db.CreateLanguageParams
@@ -141,7 +310,7 @@ func (h *AdminHandler) CreateNewLanguage(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Language created successfully",
-		"language": language,
+		"language": dto.NewLanguage(language),
 	})
 }
 
@@ -185,7 +354,7 @@ func (h *AdminHandler) CreateNewCourse(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Course created successfully",
-		"course":  course,
+		"course":  dto.NewCourse(course),
 	})
 }
 
@@ -199,9 +368,7 @@ func (h *AdminHandler) CreateNewLesson(c *gin.Context) {
 	// Convert to PostgreSQL UUID data type from string
 	courseUUID, err := utils.StringToPgTypeUUID(courseId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": fmt.Errorf("couldn't convert course ID to Postgres UUID data type %s", err),
-		})
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid lesson ID format: %s", err)})
 		return
 	}
 	req.CourseID = courseUUID
@@ -217,7 +384,7 @@ func (h *AdminHandler) CreateNewLesson(c *gin.Context) {
 	// On successful response
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Lesson created successfully",
-		"lesson":  newLesson,
+		"lesson":  dto.NewLesson(newLesson),
 	})
 
 }
@@ -234,14 +401,25 @@ func (h *AdminHandler) CreateNewExercise(c *gin.Context) {
 	// Convert to PostgreSQL UUID data type from string
 	lessonUUID, err := utils.StringToPgTypeUUID(lessonId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": fmt.Errorf("couldn't convert course ID to Postgres UUID data type %s", err),
-		})
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid lesson ID format: %s", err)})
 		return
 	}
 	req.LessonID = lessonUUID
 	// Create new user record for new exercise
-	newExercise, err := h.store.CreateExercise(c, req)
+	var newExercise db.Exercise
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		newExercise, err = q.CreateExercise(c, req)
+		if err != nil {
+			return err
+		}
+
+		// Learners enrolled in the course now have one more exercise to complete
+		lesson, err := q.GetLessonById(c, lessonUUID)
+		if err != nil {
+			return err
+		}
+		return q.RefreshCourseCompletionForCourse(c, lesson.CourseID)
+	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": fmt.Errorf("Couldn't create new exercise because %s", err),
@@ -252,8 +430,8 @@ func (h *AdminHandler) CreateNewExercise(c *gin.Context) {
 	// Successful
 	c.JSON(http.StatusOK, gin.H{
 		"message":    "Exercise created successfully",
-		"exerciseId": newExercise.ExerciseID,
-		"exercise":   newExercise,
+		"exerciseId": newExercise.ExerciseID.String(),
+		"exercise":   dto.NewExercise(newExercise),
 	})
 
 }
@@ -287,7 +465,7 @@ func (h *AdminHandler) GetAvailableLanguages(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "Available languages",
-		"languages": languages,
+		"languages": dto.NewLanguages(languages),
 	})
 }
 
@@ -301,7 +479,7 @@ func (h *AdminHandler) GetAllCourses(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "Available courses",
-		"courses": courses,
+		"courses": dto.NewCourses(courses),
 	})
 }
 
@@ -333,7 +511,7 @@ func (h *AdminHandler) GetAllLessons(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Available lessons",
-		"lessons": lessons,
+		"lessons": dto.NewLessons(lessons),
 	})
 }
 
@@ -365,7 +543,7 @@ func (h *AdminHandler) GetAllExercises(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "Available exercises",
-		"exercises": exercises,
+		"exercises": dto.NewExercises(exercises),
 	})
 }
 
@@ -395,7 +573,7 @@ func (h *AdminHandler) GetLessonsByCourseId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Lessons retrieved successfully",
-		"lessons": lessons,
+		"lessons": dto.NewCourseLessons(lessons),
 	})
 }
 
@@ -425,7 +603,7 @@ func (h *AdminHandler) GetExercisesByLessonId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "Exercises retrieved successfully",
-		"exercises": exercises,
+		"exercises": dto.NewExercises(exercises),
 	})
 }
 
@@ -455,7 +633,7 @@ func (h *AdminHandler) GetExerciseById(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Exercise retrieved successfully",
-		"exercise": exercise,
+		"exercise": dto.NewExercise(exercise),
 	})
 }
 
@@ -482,31 +660,227 @@ func (h *AdminHandler) UpdateAdminDetails(c *gin.Context) {
 
 	req.AdminID = adminUUID
 
+	current, err := h.store.GetAdminById(c, adminUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Admin not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update admin details"})
+		return
+	}
+
+	if req.Email != current.Email {
+		existing, err := h.store.GetAdminByEmail(c, req.Email)
+		if err == nil && existing.AdminID != adminUUID {
+			c.JSON(http.StatusConflict, gin.H{"error": "Email already in use"})
+			return
+		}
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check email"})
+			return
+		}
+	}
+
+	// A new email is unverified until the admin proves they own it
 	err = h.store.UpdateAdminDetails(c, req)
 	if err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Email already in use"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update admin details"})
 		return
 	}
 
+	if req.Email != current.Email {
+		contact := accountContact{ID: adminUUID, Name: req.FirstName, Email: req.Email}
+		_, err = sendVerificationEmail(c, h.store, h.tok, h.mailer, h.config, tokengen.RoleAdmin, contact, reserveAdminVerification, "/admin/verify-email")
+		if err != nil {
+			log.Printf("failed to send verification email to admin %s: %v", adminUUID.String(), err)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Admin details updated successfully",
 	})
 }
 
-// UpdateAdminPassword updates the password of an admin
+type UpdateAdminPasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// UpdateAdminPassword changes the password of the authenticated admin.
+// Every other session is logged out and the current device gets a new token pair.
 func (h *AdminHandler) UpdateAdminPassword(c *gin.Context) {
-	var req db.UpdateAdminPasswordParams
+	var req UpdateAdminPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payload, err := middleware.GetPayload(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	adminUUID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		return
+	}
+
+	admin, err := h.store.GetAdminCredentials(c, adminUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Admin not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve admin"})
+		return
+	}
+
+	if !utils.CompareHashAndPassword(admin.Password, req.CurrentPassword) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Current password is incorrect"})
+		return
+	}
+	if req.NewPassword == req.CurrentPassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "New password must be different from the current password"})
+		return
+	}
+	if err := h.policy.Validate(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process password"})
+		return
+	}
+
+	var token, refreshToken string
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		err := q.UpdateAdminPassword(c, db.UpdateAdminPasswordParams{
+			Password: hashedPassword,
+			AdminID:  admin.AdminID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Outstanding reset codes were issued for the old password
+		err = q.InvalidatePasswordResets(c, db.InvalidatePasswordResetsParams{
+			OwnerID:   admin.AdminID,
+			OwnerRole: string(tokengen.RoleAdmin),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.RevokeSessionsByOwner(c, db.RevokeSessionsByOwnerParams{
+			OwnerID:   admin.AdminID,
+			OwnerRole: string(tokengen.RoleAdmin),
+		})
+		if err != nil {
+			return err
+		}
+
+		token, refreshToken, err = startSession(c, q, h.tok, admin.AdminID, tokengen.RoleAdmin)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update admin password"})
+		return
+	}
+
+	// The password has already changed, so a failed notification shouldn't fail the request
+	content, err := utils.RenderEmail("password_changed.html", map[string]any{
+		"Name":      admin.FirstName,
+		"ChangedAt": time.Now().UTC().Format(time.RFC1123),
+		"ResetLink": fmt.Sprintf("%s/admin/forgot-password", h.config.ClientURL),
+	})
+	if err == nil {
+		err = h.mailer.SendEmail("Your Lingo password was changed", content, []string{admin.Email}, nil, nil, nil)
+	}
+	if err != nil {
+		log.Printf("failed to send password changed email to admin %s: %v", admin.AdminID.String(), err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Admin password updated successfully",
+		"token":         token,
+		"refresh_token": refreshToken,
+	})
+}
+
+// ListAdmins returns every admin account along with its role
+func (h *AdminHandler) ListAdmins(c *gin.Context) {
+	var req db.ListAdminsParams
+	limitStr := c.Query("limit")
+	offsetStr := c.Query("offset")
+
+	limitInt, err := strconv.Atoi(limitStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	offsetInt, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+	req.Limit = int32(limitInt)
+	req.Offset = int32(offsetInt)
+
+	admins, err := h.store.ListAdmins(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve admins"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Admins retrieved successfully",
+		"admins":  dto.NewAdminSummaries(admins),
+	})
+}
+
+type UpdateAdminRoleRequest struct {
+	Role rbac.Role `json:"role" binding:"required"`
+}
+
+// UpdateAdminRole assigns a new role to another admin
+func (h *AdminHandler) UpdateAdminRole(c *gin.Context) {
+	var req UpdateAdminRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if !req.Role.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return
+	}
+
 	adminId := c.Param("adminId")
 	if adminId == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Admin ID is required"})
 		return
 	}
 
+	// Stop super admins from locking themselves out by demoting their own account
+	payload, err := middleware.GetPayload(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if payload.UserID == adminId {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can't change your own role"})
+		return
+	}
+
 	// Convert adminId from string to UUID
 	adminUUID, err := utils.StringToPgTypeUUID(adminId)
 	if err != nil {
@@ -514,16 +888,58 @@ func (h *AdminHandler) UpdateAdminPassword(c *gin.Context) {
 		return
 	}
 
-	req.AdminID = adminUUID
+	_, err = h.store.GetAdminById(c, adminUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Admin not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve admin"})
+		return
+	}
 
-	err = h.store.UpdateAdminPassword(c, req)
+	err = h.store.UpdateAdminRole(c, db.UpdateAdminRoleParams{
+		Role:    string(req.Role),
+		AdminID: adminUUID,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update admin password"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update admin role"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Admin password updated successfully",
+		"message": "Admin role updated successfully",
+	})
+}
+
+type UnlockAccountRequest struct {
+	Email string        `json:"email" binding:"required,email,max=100"`
+	Role  tokengen.Role `json:"role" binding:"required,oneof=admin learner"`
+}
+
+// UnlockAccount clears the failed logins and lockout of an admin or learner account
+func (h *AdminHandler) UnlockAccount(c *gin.Context) {
+	var req UnlockAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := h.store.DeleteLoginThrottle(c, db.DeleteLoginThrottleParams{
+		Scope:       throttleScopeAccount,
+		ThrottleKey: accountThrottleKey(req.Role, req.Email),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlock account"})
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No failed logins recorded for this account"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account unlocked successfully",
 	})
 }
 
@@ -593,6 +1009,44 @@ func (h *AdminHandler) UpdateCourseById(c *gin.Context) {
 	})
 }
 
+type PublishCourseRequest struct {
+	IsPublished *bool `json:"is_published" binding:"required"`
+}
+
+// PublishCourse shows a course to learners or hides it again.
+// Learners who already enrolled keep their enrollment.
+func (h *AdminHandler) PublishCourse(c *gin.Context) {
+	var req PublishCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	courseUUID, err := utils.StringToPgTypeUUID(c.Param("courseId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid course ID format: %s", err)})
+		return
+	}
+
+	rows, err := h.store.SetCoursePublished(c, db.SetCoursePublishedParams{
+		IsPublished: *req.IsPublished,
+		CourseID:    courseUUID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update course"})
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Course not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Course publishing updated successfully",
+		"is_published": *req.IsPublished,
+	})
+}
+
 func (h *AdminHandler) UpdateLessonById(c *gin.Context) {
 	var req db.UpdateLessonDetailsParams
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -723,7 +1177,21 @@ func (h *AdminHandler) DeleteLesson(c *gin.Context) {
 		return
 	}
 
-	err = h.store.DeleteLesson(c, lessonUUID)
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		lesson, err := q.GetLessonById(c, lessonUUID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		if err := q.DeleteLesson(c, lessonUUID); err != nil {
+			return err
+		}
+		// The lesson's exercises and the progress on them are gone
+		return q.RefreshCourseCompletionForCourse(c, lesson.CourseID)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete lesson"})
 		return
@@ -748,7 +1216,20 @@ func (h *AdminHandler) DeleteExercise(c *gin.Context) {
 		return
 	}
 
-	err = h.store.DeleteExercise(c, exerciseUUID)
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		exercise, err := q.GetExerciseForGrading(c, exerciseUUID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		if err := q.DeleteExercise(c, exerciseUUID); err != nil {
+			return err
+		}
+		return q.RefreshCourseCompletionForCourse(c, exercise.CourseID)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete exercise"})
 		return
@@ -758,3 +1239,288 @@ func (h *AdminHandler) DeleteExercise(c *gin.Context) {
 		"message": "Exercise deleted successfully",
 	})
 }
+
+var errVocabularyLanguage = errors.New("vocabulary must belong to the exercise's language")
+
+type VocabularyRequest struct {
+	Word            string `json:"word" binding:"required,max=100"`
+	Translation     string `json:"translation" binding:"required,max=255"`
+	PartOfSpeech    string `json:"part_of_speech" binding:"omitempty,oneof=noun pronoun verb adjective adverb preposition conjunction interjection phrase"`
+	ToneMarked      string `json:"tone_marked" binding:"max=100"`
+	AudioUrl        string `json:"audio_url" binding:"max=255"`
+	ExampleSentence string `json:"example_sentence"`
+}
+
+type ExerciseVocabularyRequest struct {
+	VocabularyIDs []string `json:"vocabulary_ids" binding:"max=50"`
+}
+
+// optionalText stores an empty string as NULL
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func (h *AdminHandler) CreateVocabulary(c *gin.Context) {
+	var req VocabularyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	languageUUID, err := utils.StringToPgTypeUUID(c.Param("languageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language ID format"})
+		return
+	}
+
+	_, err = h.store.GetLanguageById(c, languageUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Language not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check language"})
+		return
+	}
+
+	vocabulary, err := h.store.CreateVocabulary(c, db.CreateVocabularyParams{
+		LanguageID:      languageUUID,
+		Word:            req.Word,
+		Translation:     req.Translation,
+		PartOfSpeech:    optionalText(req.PartOfSpeech),
+		ToneMarked:      optionalText(req.ToneMarked),
+		AudioUrl:        optionalText(req.AudioUrl),
+		ExampleSentence: optionalText(req.ExampleSentence),
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "This word and translation already exist for the language"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create vocabulary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Vocabulary created successfully",
+		"vocabulary": dto.NewVocabulary(vocabulary),
+	})
+}
+
+func (h *AdminHandler) GetVocabularyById(c *gin.Context) {
+	vocabularyUUID, err := utils.StringToPgTypeUUID(c.Param("vocabularyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid vocabulary ID format: %s", err)})
+		return
+	}
+
+	vocabulary, err := h.store.GetVocabularyById(c, vocabularyUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Vocabulary not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vocabulary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Vocabulary retrieved successfully",
+		"vocabulary": dto.NewVocabulary(vocabulary),
+	})
+}
+
+// GetVocabularyByLanguage lists a language's vocabulary alphabetically, a page at a time
+func (h *AdminHandler) GetVocabularyByLanguage(c *gin.Context) {
+	languageUUID, err := utils.StringToPgTypeUUID(c.Param("languageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid language ID format: %s", err)})
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	words, err := h.store.ListVocabularyByLanguage(c, db.ListVocabularyByLanguageParams{
+		LanguageID: languageUUID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vocabulary"})
+		return
+	}
+
+	total, err := h.store.CountVocabularyByLanguage(c, languageUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vocabulary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Vocabulary retrieved successfully",
+		"total":      total,
+		"vocabulary": dto.NewVocabularyList(words),
+	})
+}
+
+func (h *AdminHandler) UpdateVocabularyById(c *gin.Context) {
+	var req VocabularyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vocabularyUUID, err := utils.StringToPgTypeUUID(c.Param("vocabularyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid vocabulary ID format: %s", err)})
+		return
+	}
+
+	vocabulary, err := h.store.UpdateVocabulary(c, db.UpdateVocabularyParams{
+		Word:            req.Word,
+		Translation:     req.Translation,
+		PartOfSpeech:    optionalText(req.PartOfSpeech),
+		ToneMarked:      optionalText(req.ToneMarked),
+		AudioUrl:        optionalText(req.AudioUrl),
+		ExampleSentence: optionalText(req.ExampleSentence),
+		VocabularyID:    vocabularyUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Vocabulary not found"})
+			return
+		}
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "This word and translation already exist for the language"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update vocabulary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Vocabulary updated successfully",
+		"vocabulary": dto.NewVocabulary(vocabulary),
+	})
+}
+
+// DeleteVocabulary removes a word, unlinking it from every exercise that used it
+func (h *AdminHandler) DeleteVocabulary(c *gin.Context) {
+	vocabularyUUID, err := utils.StringToPgTypeUUID(c.Param("vocabularyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid vocabulary ID format: %s", err)})
+		return
+	}
+
+	deleted, err := h.store.DeleteVocabulary(c, vocabularyUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete vocabulary"})
+		return
+	}
+	if deleted == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Vocabulary not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Vocabulary deleted successfully",
+	})
+}
+
+func (h *AdminHandler) GetExerciseVocabulary(c *gin.Context) {
+	exerciseUUID, err := utils.StringToPgTypeUUID(c.Param("exerciseId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid exercise ID format: %s", err)})
+		return
+	}
+
+	words, err := h.store.ListExerciseVocabulary(c, exerciseUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vocabulary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Vocabulary retrieved successfully",
+		"vocabulary": dto.NewVocabularyList(words),
+	})
+}
+
+// SetExerciseVocabulary replaces the words an exercise teaches. Every word must
+// belong to the language of the exercise's course.
+func (h *AdminHandler) SetExerciseVocabulary(c *gin.Context) {
+	var req ExerciseVocabularyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	exerciseUUID, err := utils.StringToPgTypeUUID(c.Param("exerciseId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid exercise ID format: %s", err)})
+		return
+	}
+
+	vocabularyIDs := make([]pgtype.UUID, 0, len(req.VocabularyIDs))
+	for _, id := range req.VocabularyIDs {
+		vocabularyUUID, err := utils.StringToPgTypeUUID(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid vocabulary ID format: %s", err)})
+			return
+		}
+		vocabularyIDs = append(vocabularyIDs, vocabularyUUID)
+	}
+
+	var words []db.Vocabulary
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		languageID, err := q.GetExerciseLanguage(c, exerciseUUID)
+		if err != nil {
+			return err
+		}
+
+		for _, vocabularyID := range vocabularyIDs {
+			vocabulary, err := q.GetVocabularyById(c, vocabularyID)
+			if err != nil {
+				return err
+			}
+			if vocabulary.LanguageID != languageID {
+				return errVocabularyLanguage
+			}
+		}
+
+		if err := q.DeleteExerciseVocabulary(c, exerciseUUID); err != nil {
+			return err
+		}
+		for _, vocabularyID := range vocabularyIDs {
+			err := q.CreateExerciseVocabulary(c, db.CreateExerciseVocabularyParams{
+				ExerciseID:   exerciseUUID,
+				VocabularyID: vocabularyID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		words, err = q.ListExerciseVocabulary(c, exerciseUUID)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Exercise or vocabulary not found"})
+		case errors.Is(err, errVocabularyLanguage):
+			c.JSON(http.StatusBadRequest, gin.H{"error": errVocabularyLanguage.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update exercise vocabulary"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Exercise vocabulary updated successfully",
+		"vocabulary": dto.NewVocabularyList(words),
+	})
+}
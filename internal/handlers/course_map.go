@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/pkg/unlock"
+	"lingo/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CourseMapHandler shows learners which lessons of a course they have unlocked
+type CourseMapHandler struct {
+	store *db.SQLStore
+}
+
+func NewCourseMapHandler(store *db.SQLStore) *CourseMapHandler {
+	return &CourseMapHandler{
+		store: store,
+	}
+}
+
+// courseProgress loads a learner's progress on each lesson of a course, in
+// lesson order, and works out which lessons they have unlocked
+func courseProgress(ctx context.Context, q db.Querier, userID, courseID pgtype.UUID) ([]db.ListCourseLessonProgressRow, []unlock.Lesson, []string, error) {
+	rows, err := q.ListCourseLessonProgress(ctx, db.ListCourseLessonProgressParams{
+		UserID:   userID,
+		CourseID: courseID,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	lessons := make([]unlock.Lesson, 0, len(rows))
+	for _, r := range rows {
+		lesson := unlock.Lesson{
+			Override:   r.IsUnlocked.Bool,
+			Checkpoint: r.IsCheckpoint,
+			Exercises:  r.TotalExercises,
+			Completed:  r.CompletedExercises,
+			TotalScore: r.TotalScore,
+		}
+		if r.UnlockScore.Valid {
+			lesson.MinScore = &r.UnlockScore.Int32
+		}
+		lessons = append(lessons, lesson)
+	}
+	return rows, lessons, unlock.Statuses(lessons), nil
+}
+
+// requireUnlocked writes a 403 response and returns false if the learner hasn't unlocked the lesson
+func requireUnlocked(c *gin.Context, q db.Querier, userID, courseID, lessonID pgtype.UUID) bool {
+	rows, _, statuses, err := courseProgress(c, q, userID, courseID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check lesson progress"})
+		return false
+	}
+
+	for i, r := range rows {
+		if r.LessonID == lessonID && statuses[i] == unlock.StatusLocked {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Complete the previous lesson to unlock this one"})
+			return false
+		}
+	}
+	return true
+}
+
+// GetCourseMap returns every lesson of a course the learner is enrolled in, with
+// whether it is locked, unlocked or completed and the learner's average score on it
+func (h *CourseMapHandler) GetCourseMap(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := utils.StringToPgTypeUUID(c.Param("courseId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID format"})
+		return
+	}
+	if !requireEnrollment(c, h.store, userID, courseID) {
+		return
+	}
+
+	rows, lessons, statuses, err := courseProgress(c, h.store, userID, courseID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve course map"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Course map retrieved successfully",
+		"lessons": dto.NewCourseMap(rows, lessons, statuses),
+	})
+}
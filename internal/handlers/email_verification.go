@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/auth/tokengen"
+	"lingo/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	emailVerificationDuration  = 24 * time.Hour
+	verificationResendInterval = 2 * time.Minute
+)
+
+var errEmailNotVerified = errors.New("please verify your email before logging in")
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email,max=100"`
+}
+
+// reserveVerificationFunc starts the resend cooldown of an account, returning
+// 0 rows affected if it is already verified or was emailed too recently
+type reserveVerificationFunc func(ctx context.Context, q db.Querier, ownerID pgtype.UUID, sentAt, resendBefore pgtype.Timestamp) (int64, error)
+
+// sendVerificationEmail emails a signed verification link to the account unless it
+// is already verified or was sent one within the resend interval. It reports
+// whether an email went out. The cooldown is claimed before sending so no
+// transaction is held open during delivery, and it is kept if the email fails.
+func sendVerificationEmail(
+	c *gin.Context,
+	store *db.SQLStore,
+	tok tokengen.Maker,
+	mailer utils.EmailSender,
+	config utils.Config,
+	role tokengen.Role,
+	account accountContact,
+	reserve reserveVerificationFunc,
+	linkPath string,
+) (bool, error) {
+	now := time.Now().UTC()
+
+	rows, err := reserve(c, store, account.ID,
+		pgtype.Timestamp{Time: now, Valid: true},
+		pgtype.Timestamp{Time: now.Add(-verificationResendInterval), Valid: true},
+	)
+	if err != nil {
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	token, payload, err := tok.CreateEmailVerificationToken(account.ID, role, account.Email, emailVerificationDuration)
+	if err != nil {
+		return false, err
+	}
+
+	content, err := utils.RenderEmail("verify_email.html", map[string]any{
+		"Name":      account.Name,
+		"Link":      fmt.Sprintf("%s%s?token=%s", config.ClientURL, linkPath, token),
+		"Code":      token,
+		"ExpiresAt": payload.ExpiredAt.UTC().Format(time.RFC1123),
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := mailer.SendEmail("Verify your Lingo email", content, []string{account.Email}, nil, nil, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// resendVerification emails a new verification link to the account with the given email.
+// The response is the same whether or not the account exists, is already verified
+// or is still in its resend cooldown, so emails can't be enumerated.
+func resendVerification(
+	c *gin.Context,
+	store *db.SQLStore,
+	tok tokengen.Maker,
+	mailer utils.EmailSender,
+	config utils.Config,
+	role tokengen.Role,
+	lookup func(ctx context.Context, email string) (accountContact, error),
+	reserve reserveVerificationFunc,
+	linkPath string,
+) {
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sent := gin.H{"message": "if an unverified account exists for this email, a verification email has been sent"}
+
+	account, err := lookup(c, req.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusOK, sent)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process request"})
+		return
+	}
+
+	// A failed send is only logged, since answering differently would reveal that the account exists
+	if _, err := sendVerificationEmail(c, store, tok, mailer, config, role, account, reserve, linkPath); err != nil {
+		log.Printf("failed to send verification email to %s %s: %v", role, account.ID.String(), err)
+	}
+
+	c.JSON(http.StatusOK, sent)
+}
+
+// verifyEmail consumes a verification token and marks the account's email as verified.
+// Tokens are bound to the address they were sent to, so changing the email
+// invalidates links sent to the old one.
+func verifyEmail(
+	c *gin.Context,
+	tok tokengen.Maker,
+	role tokengen.Role,
+	loadEmail func(ctx context.Context, ownerID pgtype.UUID) (string, error),
+	markVerified func(ctx context.Context, ownerID pgtype.UUID, verifiedAt pgtype.Timestamp) (int64, error),
+) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payload, err := tok.VerifyEmailVerificationToken(req.Token)
+	if err != nil {
+		if errors.Is(err, tokengen.ErrExpiredToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "verification link has expired, please request a new one"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid verification link"})
+		return
+	}
+	if payload.Role != role {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid verification link"})
+		return
+	}
+
+	ownerID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid verification link"})
+		return
+	}
+
+	email, err := loadEmail(c, ownerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid verification link"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify email"})
+		return
+	}
+	if email != payload.Email {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid verification link"})
+		return
+	}
+
+	rows, err := markVerified(c, ownerID, pgtype.Timestamp{Time: time.Now().UTC(), Valid: true})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify email"})
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "email already verified"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "email verified successfully"})
+}
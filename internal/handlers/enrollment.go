@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// uniqueViolation is the Postgres error code for a duplicate key
+const uniqueViolation = "23505"
+
+var errCourseRequiresSubscription = errors.New("course requires a subscription")
+
+// EnrollmentHandler lets learners browse published courses and manage their enrollments
+type EnrollmentHandler struct {
+	store *db.SQLStore
+}
+
+func NewEnrollmentHandler(store *db.SQLStore) *EnrollmentHandler {
+	return &EnrollmentHandler{
+		store: store,
+	}
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// requireEnrollment responds with an error unless the learner is enrolled in the course
+func requireEnrollment(c *gin.Context, q db.Querier, userID, courseID pgtype.UUID) bool {
+	_, err := q.GetUserCourse(c, db.GetUserCourseParams{
+		UserID:   userID,
+		CourseID: courseID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Enroll in the course first"})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check enrollment"})
+		return false
+	}
+	return true
+}
+
+// ListLanguages returns the languages learners can pick courses from
+func (h *EnrollmentHandler) ListLanguages(c *gin.Context) {
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	languages, err := h.store.GetAllLanguages(c, db.GetAllLanguagesParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve languages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Available languages",
+		"languages": dto.NewLanguages(languages),
+	})
+}
+
+// ListLanguageCourses returns the published courses for a language
+func (h *EnrollmentHandler) ListLanguageCourses(c *gin.Context) {
+	languageID, err := utils.StringToPgTypeUUID(c.Param("languageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language ID format"})
+		return
+	}
+
+	_, err = h.store.GetLanguageById(c, languageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Language not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check language"})
+		return
+	}
+
+	courses, err := h.store.ListPublishedCoursesByLanguage(c, languageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve courses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Available courses",
+		"courses": dto.NewCourses(courses),
+	})
+}
+
+// EnrollInCourse enrolls the authenticated learner in a published course.
+// Paid courses are refused until subscriptions exist.
+func (h *EnrollmentHandler) EnrollInCourse(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := utils.StringToPgTypeUUID(c.Param("courseId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID format"})
+		return
+	}
+
+	// Unpublished courses are hidden from learners, so they are reported as missing
+	course, err := h.store.GetPublishedCourse(c, courseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Course not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve course"})
+		return
+	}
+
+	if course.IsFree.Valid && !course.IsFree.Bool {
+		c.JSON(http.StatusPaymentRequired, gin.H{"error": errCourseRequiresSubscription.Error()})
+		return
+	}
+
+	_, err = h.store.GetUserCourse(c, db.GetUserCourseParams{
+		UserID:   userID,
+		CourseID: courseID,
+	})
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Already enrolled in this course"})
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check enrollment"})
+		return
+	}
+
+	var enrollment db.UserCourse
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		enrollment, err = q.CreateUserCourse(c, db.CreateUserCourseParams{
+			UserID:   userID,
+			CourseID: courseID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Progress from an earlier enrollment in the course still counts
+		enrollment.CompletionPercentage, err = q.RefreshCourseCompletion(c, db.RefreshCourseCompletionParams{
+			UserID:   userID,
+			CourseID: courseID,
+		})
+		return err
+	})
+	if err != nil {
+		// Another request enrolled the learner after our check
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Already enrolled in this course"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enroll in course"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Enrolled successfully",
+		"course":     dto.NewCourse(course),
+		"enrollment": dto.NewEnrollment(enrollment),
+	})
+}
+
+// UnenrollFromCourse removes the authenticated learner from a course
+func (h *EnrollmentHandler) UnenrollFromCourse(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := utils.StringToPgTypeUUID(c.Param("courseId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID format"})
+		return
+	}
+
+	rows, err := h.store.DeleteUserCourse(c, db.DeleteUserCourseParams{
+		UserID:   userID,
+		CourseID: courseID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unenroll from course"})
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not enrolled in this course"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unenrolled successfully"})
+}
+
+// ListMyCourses returns the authenticated learner's enrollments and their progress
+func (h *EnrollmentHandler) ListMyCourses(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	courses, err := h.store.ListUserCourses(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve enrolled courses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Enrolled courses retrieved successfully",
+		"courses": dto.NewEnrolledCourses(courses),
+	})
+}
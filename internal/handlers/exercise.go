@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/pkg/grading"
+	"lingo/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ExerciseHandler serves exercises to enrolled learners and grades their answers
+type ExerciseHandler struct {
+	store *db.SQLStore
+}
+
+func NewExerciseHandler(store *db.SQLStore) *ExerciseHandler {
+	return &ExerciseHandler{
+		store: store,
+	}
+}
+
+type SubmitAnswerRequest struct {
+	Answer string `json:"answer" binding:"required,max=500"`
+}
+
+// ListLessonExercises returns a lesson's exercises without their correct answers
+func (h *ExerciseHandler) ListLessonExercises(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	lessonID, err := utils.StringToPgTypeUUID(c.Param("lessonId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lesson ID format"})
+		return
+	}
+
+	lesson, err := h.store.GetLessonById(c, lessonID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Lesson not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve lesson"})
+		return
+	}
+	if !requireEnrollment(c, h.store, userID, lesson.CourseID) {
+		return
+	}
+	if !requireUnlocked(c, h.store, userID, lesson.CourseID, lessonID) {
+		return
+	}
+
+	exercises, err := h.store.ListLessonExercises(c, lessonID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve exercises"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Exercises retrieved successfully",
+		"exercises": dto.NewLearnerExercises(exercises),
+	})
+}
+
+// SubmitAnswer grades a learner's answer and records their progress on the exercise.
+// Retrying keeps the best score, and an exercise stays completed once passed.
+func (h *ExerciseHandler) SubmitAnswer(c *gin.Context) {
+	var req SubmitAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	exerciseID, err := utils.StringToPgTypeUUID(c.Param("exerciseId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exercise ID format"})
+		return
+	}
+
+	exercise, err := h.store.GetExerciseForGrading(c, exerciseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Exercise not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve exercise"})
+		return
+	}
+	if !requireEnrollment(c, h.store, userID, exercise.CourseID) {
+		return
+	}
+	if !requireUnlocked(c, h.store, userID, exercise.CourseID, exercise.LessonID) {
+		return
+	}
+
+	result, err := grading.Grade(exercise.ExerciseType.String, exercise.CorrectAnswer, exercise.Options, req.Answer)
+	if err != nil {
+		if errors.Is(err, grading.ErrInvalidAnswer) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("failed to grade exercise %s: %v", exerciseID.String(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to grade answer"})
+		return
+	}
+
+	now := time.Now().UTC()
+	// Last week's league has to be settled before XP places the learner in this week's
+	if result.Correct {
+		if err := settleLeagues(c, h.store, userID, now); err != nil {
+			log.Printf("failed to settle leagues for %s: %v", userID.String(), err)
+		}
+	}
+
+	var progress db.UserProgress
+	var completion pgtype.Float8
+	var streakInfo *dto.Streak
+	var awarded []db.XpLedger
+	var review *dto.Review
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		progress, err = q.RecordExerciseAttempt(c, db.RecordExerciseAttemptParams{
+			UserID:      userID,
+			LessonID:    exercise.LessonID,
+			ExerciseID:  exerciseID,
+			IsCompleted: pgtype.Bool{Bool: result.Correct, Valid: true},
+			Score:       pgtype.Int4{Int32: int32(result.Score), Valid: true},
+			CompletedAt: pgtype.Timestamp{Time: now, Valid: result.Correct},
+		})
+		if err != nil {
+			return err
+		}
+
+		completion, err = q.RefreshCourseCompletion(c, db.RefreshCourseCompletionParams{
+			UserID:   userID,
+			CourseID: exercise.CourseID,
+		})
+		if err != nil {
+			return err
+		}
+
+		item, err := scheduleReview(c, q, userID, exerciseID, progress.IsCompleted.Bool, result.Score, now)
+		if err != nil {
+			return err
+		}
+		if item != nil {
+			r := dto.NewReview(*item)
+			review = &r
+		}
+
+		// Passing an exercise is what counts towards the daily streak and lesson XP
+		if result.Correct {
+			s, err := recordStreakActivity(c, q, userID, now)
+			if err != nil {
+				return err
+			}
+			streakInfo = &s
+
+			lessonXP, err := recordLessonXP(c, q, userID, exercise.LessonID, now)
+			if err != nil {
+				return err
+			}
+			streakXP, err := recordStreakXP(c, q, userID, s, now)
+			if err != nil {
+				return err
+			}
+			awarded = append(lessonXP, streakXP...)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record progress"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Answer submitted",
+		"result":  dto.NewSubmission(result, exercise.CorrectAnswer, progress, completion),
+		"streak":  streakInfo,
+		"xp":      dto.NewXPAwards(awarded),
+		"review":  review,
+	})
+}
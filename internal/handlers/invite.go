@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/middleware"
+	"lingo/pkg/auth/rbac"
+	"lingo/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const adminInviteDuration = 72 * time.Hour
+
+var (
+	errInvalidInvite = errors.New("invite is invalid, expired or already used")
+	errAdminExists   = errors.New("an admin with this email already exists")
+)
+
+type InviteAdminRequest struct {
+	Email string    `json:"email" binding:"required,email,max=100"`
+	Role  rbac.Role `json:"role" binding:"required"`
+}
+
+// InviteAdmin creates a single use invite for an email address and emails it to them
+func (h *AdminHandler) InviteAdmin(c *gin.Context) {
+	var req InviteAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !req.Role.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return
+	}
+
+	payload, err := middleware.GetPayload(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	inviterID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		return
+	}
+
+	_, err = h.store.GetAdminByEmail(c, req.Email)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": errAdminExists.Error()})
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check admin"})
+		return
+	}
+
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invite"})
+		return
+	}
+	expiresAt := time.Now().UTC().Add(adminInviteDuration)
+
+	content, err := utils.RenderEmail("admin_invite.html", map[string]any{
+		"Role":      req.Role,
+		"Link":      fmt.Sprintf("%s/admin/accept-invite?token=%s", h.config.ClientURL, token),
+		"Code":      token,
+		"ExpiresAt": expiresAt.Format(time.RFC1123),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invite"})
+		return
+	}
+
+	_, err = h.store.CreateAdminInvite(c, db.CreateAdminInviteParams{
+		Email:     req.Email,
+		Role:      string(req.Role),
+		TokenHash: utils.HashToken(token),
+		InvitedBy: inviterID,
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invite"})
+		return
+	}
+
+	// The email goes out after the invite is saved so no connection is held open
+	// during delivery. If it fails, the invite can never be redeemed since only its
+	// hash is stored, and it expires on its own.
+	err = h.mailer.SendEmail("You've been invited to Lingo", content, []string{req.Email}, nil, nil, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send invite"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Invite sent successfully",
+		"email":      req.Email,
+		"expires_at": expiresAt,
+	})
+}
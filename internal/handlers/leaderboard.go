@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	maxFriends = 100
+	// defaultLeaderboardRefreshInterval is used when LEADERBOARD_REFRESH_INTERVAL isn't set
+	defaultLeaderboardRefreshInterval = 5 * time.Minute
+)
+
+// LeaderboardHandler ranks learners by XP globally, per language and among friends
+type LeaderboardHandler struct {
+	store *db.SQLStore
+}
+
+func NewLeaderboardHandler(store *db.SQLStore) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		store: store,
+	}
+}
+
+// RefreshLeaderboards recalculates the global and language leaderboards
+func (h *LeaderboardHandler) RefreshLeaderboards(ctx context.Context) error {
+	if err := h.store.RefreshGlobalLeaderboard(ctx); err != nil {
+		return err
+	}
+	return h.store.RefreshLanguageLeaderboard(ctx)
+}
+
+// RefreshPeriodically refreshes the leaderboards every interval until ctx is done
+func (h *LeaderboardHandler) RefreshPeriodically(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultLeaderboardRefreshInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := h.RefreshLeaderboards(ctx); err != nil {
+				log.Printf("failed to refresh leaderboards: %v", err)
+			}
+		}
+	}
+}
+
+// GetGlobalLeaderboard returns a page of learners ranked by all-time XP, and the
+// authenticated learner's own rank. Ranks are refreshed periodically, so they can
+// lag behind the XP learners have just earned.
+func (h *LeaderboardHandler) GetGlobalLeaderboard(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.store.ListGlobalLeaderboard(c, db.ListGlobalLeaderboardParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve leaderboard"})
+		return
+	}
+
+	var me *dto.LeaderboardEntry
+	entry, err := h.store.GetGlobalLeaderboardEntry(c, userID)
+	switch {
+	case err == nil:
+		e := dto.NewGlobalLeaderboardEntry(entry)
+		me = &e
+	case !errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve leaderboard"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Leaderboard retrieved successfully",
+		"leaderboard": dto.NewGlobalLeaderboard(entries),
+		"me":          me,
+	})
+}
+
+// GetLanguageLeaderboard ranks learners by the XP earned from a language's lessons
+func (h *LeaderboardHandler) GetLanguageLeaderboard(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	languageID, err := utils.StringToPgTypeUUID(c.Param("languageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language ID format"})
+		return
+	}
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.store.ListLanguageLeaderboard(c, db.ListLanguageLeaderboardParams{
+		LanguageID: languageID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve leaderboard"})
+		return
+	}
+
+	var me *dto.LeaderboardEntry
+	entry, err := h.store.GetLanguageLeaderboardEntry(c, db.GetLanguageLeaderboardEntryParams{
+		LanguageID: languageID,
+		UserID:     userID,
+	})
+	switch {
+	case err == nil:
+		e := dto.NewLanguageLeaderboardEntry(entry)
+		me = &e
+	case !errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve leaderboard"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Leaderboard retrieved successfully",
+		"leaderboard": dto.NewLanguageLeaderboard(entries),
+		"me":          me,
+	})
+}
+
+// GetFriendsLeaderboard ranks the authenticated learner and the learners they
+// have added as friends by all-time XP
+func (h *LeaderboardHandler) GetFriendsLeaderboard(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.store.ListFriendsLeaderboard(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve leaderboard"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Leaderboard retrieved successfully",
+		"leaderboard": dto.NewFriendsLeaderboard(entries),
+	})
+}
+
+// friendID reads the friendId path parameter, writing an error response if it
+// is invalid or is the learner themselves
+func friendID(c *gin.Context, userID pgtype.UUID) (pgtype.UUID, bool) {
+	id, err := utils.StringToPgTypeUUID(c.Param("friendId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid friend ID format"})
+		return pgtype.UUID{}, false
+	}
+	if id == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You can't add yourself as a friend"})
+		return pgtype.UUID{}, false
+	}
+	return id, true
+}
+
+// AddFriend adds a learner to the authenticated learner's friends leaderboard
+func (h *LeaderboardHandler) AddFriend(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+	friend, ok := friendID(c, userID)
+	if !ok {
+		return
+	}
+
+	if _, err := h.store.GetUserById(c, friend); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve learner"})
+		return
+	}
+
+	count, err := h.store.CountFriendships(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add friend"})
+		return
+	}
+	if count >= maxFriends {
+		c.JSON(http.StatusConflict, gin.H{"error": "You can have at most " + strconv.Itoa(maxFriends) + " friends"})
+		return
+	}
+
+	err = h.store.CreateFriendship(c, db.CreateFriendshipParams{
+		UserID:   userID,
+		FriendID: friend,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add friend"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Friend added successfully"})
+}
+
+// RemoveFriend removes a learner from the authenticated learner's friends leaderboard
+func (h *LeaderboardHandler) RemoveFriend(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+	friend, ok := friendID(c, userID)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.store.DeleteFriendship(c, db.DeleteFriendshipParams{
+		UserID:   userID,
+		FriendID: friend,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove friend"})
+		return
+	}
+	if deleted == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Friend not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Friend removed successfully"})
+}
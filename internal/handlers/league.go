@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/pkg/league"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LeagueHandler shows learners their weekly league
+type LeagueHandler struct {
+	store *db.SQLStore
+}
+
+func NewLeagueHandler(store *db.SQLStore) *LeagueHandler {
+	return &LeagueHandler{
+		store: store,
+	}
+}
+
+func leagueWeek(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: league.WeekStart(t), Valid: true}
+}
+
+// recordLeagueXP adds newly awarded XP to the learner's league for the week,
+// placing them in an open league of their tier if it's their first XP this week.
+// It should run in the same transaction that awards the XP.
+func recordLeagueXP(ctx context.Context, q db.Querier, userID pgtype.UUID, amount int32, now time.Time) error {
+	week := leagueWeek(now)
+	updated, err := q.AddLeagueXP(ctx, db.AddLeagueXPParams{
+		Amount:    amount,
+		UserID:    userID,
+		WeekStart: week,
+	})
+	if err != nil {
+		return err
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	tier, err := q.GetUserLeagueTier(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	l, err := q.GetOpenLeagueForUpdate(ctx, db.GetOpenLeagueForUpdateParams{
+		WeekStart:  week,
+		Tier:       tier,
+		MaxMembers: league.Size,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		l, err = q.CreateLeague(ctx, db.CreateLeagueParams{
+			Tier:      tier,
+			WeekStart: week,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := q.IncrementLeagueMembers(ctx, l.LeagueID); err != nil {
+		return err
+	}
+	_, err = q.CreateLeagueMember(ctx, db.CreateLeagueMemberParams{
+		LeagueID:  l.LeagueID,
+		UserID:    userID,
+		WeekStart: week,
+		Xp:        amount,
+		JoinedAt:  pgtype.Timestamp{Time: now, Valid: true},
+	})
+	return err
+}
+
+// settleLeague ranks a finished league and promotes or demotes its members.
+// A league that is already settled is left as it is.
+func settleLeague(ctx context.Context, q db.Querier, leagueID pgtype.UUID, now time.Time) error {
+	l, err := q.GetUnsettledLeagueForUpdate(ctx, leagueID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	standings, err := q.ListLeagueStandings(ctx, leagueID)
+	if err != nil {
+		return err
+	}
+	for i, m := range standings {
+		outcome := league.Outcome(l.Tier, i+1, len(standings))
+		err = q.SettleLeagueMember(ctx, db.SettleLeagueMemberParams{
+			FinalRank: pgtype.Int4{Int32: int32(i + 1), Valid: true},
+			Outcome:   pgtype.Text{String: outcome, Valid: true},
+			LeagueID:  leagueID,
+			UserID:    m.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.SetUserLeagueTier(ctx, db.SetUserLeagueTierParams{
+			LeagueTier: league.NextTier(l.Tier, outcome),
+			UserID:     m.UserID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return q.SettleLeague(ctx, db.SettleLeagueParams{
+		SettledAt: pgtype.Timestamp{Time: now, Valid: true},
+		LeagueID:  leagueID,
+	})
+}
+
+// settleLeagues settles the learner's leagues from earlier weeks, each in its own
+// transaction. Leagues are settled lazily, so this has to run before the learner
+// joins a new league or looks at their tier.
+func settleLeagues(ctx context.Context, store *db.SQLStore, userID pgtype.UUID, now time.Time) error {
+	leagueIDs, err := store.ListUnsettledUserLeagues(ctx, db.ListUnsettledUserLeaguesParams{
+		UserID:    userID,
+		WeekStart: leagueWeek(now),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, leagueID := range leagueIDs {
+		err = store.ExecTx(ctx, func(q db.Querier) error {
+			return settleLeague(ctx, q, leagueID, now)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMyLeague returns the authenticated learner's tier, this week's league
+// standings and how they finished last week. Learners join a league when they
+// first earn XP in a week, so league is null until then.
+func (h *LeagueHandler) GetMyLeague(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	if err := settleLeagues(c, h.store, userID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to settle previous leagues"})
+		return
+	}
+
+	tier, err := h.store.GetUserLeagueTier(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve league"})
+		return
+	}
+
+	var current *dto.League
+	l, err := h.store.GetUserLeague(c, db.GetUserLeagueParams{
+		UserID:    userID,
+		WeekStart: leagueWeek(now),
+	})
+	switch {
+	case err == nil:
+		standings, err := h.store.ListLeagueStandings(c, l.LeagueID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve league standings"})
+			return
+		}
+		info := dto.NewLeague(l, standings)
+		current = &info
+	case !errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve league"})
+		return
+	}
+
+	var lastWeek *dto.LeagueResult
+	result, err := h.store.GetUserLeagueResult(c, db.GetUserLeagueResultParams{
+		UserID:    userID,
+		WeekStart: leagueWeek(now.AddDate(0, 0, -7)),
+	})
+	switch {
+	case err == nil:
+		r := dto.NewLeagueResult(result)
+		lastWeek = &r
+	case !errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve last week's league"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "League retrieved successfully",
+		"tier":      tier,
+		"tier_name": league.TierName(tier),
+		"league":    current,
+		"last_week": lastWeek,
+	})
+}
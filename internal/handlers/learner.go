@@ -1 +1,225 @@
 package handlers
+
+import (
+	"context"
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/pkg/auth/tokengen"
+	"lingo/utils"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type LearnerHandler struct {
+	store  *db.SQLStore
+	tok    tokengen.Maker
+	mailer utils.EmailSender
+	config utils.Config
+	policy *utils.PasswordPolicy
+}
+
+func NewLearnerHandler(store *db.SQLStore, tok tokengen.Maker, mailer utils.EmailSender, config utils.Config, policy *utils.PasswordPolicy) *LearnerHandler {
+	return &LearnerHandler{
+		store:  store,
+		tok:    tok,
+		mailer: mailer,
+		config: config,
+		policy: policy,
+	}
+}
+
+type LearnerSignupRequest struct {
+	Username string `json:"username" binding:"required,alphanum,min=3,max=50"`
+	Email    string `json:"email" binding:"required,email,max=100"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RegisterLearner creates a new learner account
+func (h *LearnerHandler) RegisterLearner(c *gin.Context) {
+	var req LearnerSignupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Check if the email or username is already taken
+	_, err := h.store.GetUserByEmail(c, req.Email)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "email already in use"})
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check email"})
+		return
+	}
+
+	_, err = h.store.GetUserByUsername(c, req.Username)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "username already taken"})
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check username"})
+		return
+	}
+
+	if err := h.policy.Validate(req.Password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process password"})
+		return
+	}
+
+	user, err := h.store.CreateUser(c, db.CreateUserParams{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: hashedPassword,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create learner"})
+		return
+	}
+
+	// The account is usable without the email, the learner can ask for a new one
+	contact := accountContact{ID: user.UserID, Name: user.Username, Email: user.Email}
+	_, err = sendVerificationEmail(c, h.store, h.tok, h.mailer, h.config, tokengen.RoleLearner, contact, reserveLearnerVerification, "/verify-email")
+	if err != nil {
+		log.Printf("failed to send verification email to learner %s: %v", user.UserID.String(), err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Learner created successfully, check your email to verify your account",
+		"user":    dto.NewRegisteredLearner(user),
+	})
+}
+
+// LoginLearner verifies a learner's credentials and issues an access/refresh token pair
+func (h *LearnerHandler) LoginLearner(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	wait, err := checkLoginThrottle(c, h.store, tokengen.RoleLearner, req.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+	if wait > 0 {
+		respondWithLoginThrottle(c, wait)
+		return
+	}
+
+	user, err := h.store.GetUserForLogin(c, req.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			failLogin(c, h.store, h.mailer, h.config, tokengen.RoleLearner, req.Email, h.learnerContact, "/forgot-password")
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+
+	if !utils.CompareHashAndPassword(user.Password, req.Password) {
+		failLogin(c, h.store, h.mailer, h.config, tokengen.RoleLearner, req.Email, h.learnerContact, "/forgot-password")
+		return
+	}
+
+	if err := clearLoginFailures(c, h.store, tokengen.RoleLearner, req.Email); err != nil {
+		log.Printf("failed to clear failed logins: %v", err)
+	}
+
+	if h.config.RequireEmailVerification && !user.EmailVerifiedAt.Valid {
+		c.JSON(http.StatusForbidden, gin.H{"error": errEmailNotVerified.Error()})
+		return
+	}
+
+	token, refreshToken, err := startSession(c, h.store, h.tok, user.UserID, tokengen.RoleLearner)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "login successful",
+		"token":         token,
+		"refresh_token": refreshToken,
+	})
+}
+
+// RefreshLearnerToken rotates a learner's refresh token and issues a new token pair
+func (h *LearnerHandler) RefreshLearnerToken(c *gin.Context) {
+	refreshSession(c, h.store, h.tok, tokengen.RoleLearner)
+}
+
+// LogoutLearner revokes the session of the presented refresh token
+func (h *LearnerHandler) LogoutLearner(c *gin.Context) {
+	logoutSession(c, h.store, h.tok, tokengen.RoleLearner)
+}
+
+// LogoutAllLearnerDevices revokes every session of the authenticated learner
+func (h *LearnerHandler) LogoutAllLearnerDevices(c *gin.Context) {
+	logoutAllSessions(c, h.store, tokengen.RoleLearner)
+}
+
+// learnerContact looks up the learner that emails for the given address should go to
+func (h *LearnerHandler) learnerContact(ctx context.Context, email string) (accountContact, error) {
+	user, err := h.store.GetUserByEmail(ctx, email)
+	if err != nil {
+		return accountContact{}, err
+	}
+	return accountContact{ID: user.UserID, Name: user.Username, Email: user.Email}, nil
+}
+
+func reserveLearnerVerification(ctx context.Context, q db.Querier, ownerID pgtype.UUID, sentAt, resendBefore pgtype.Timestamp) (int64, error) {
+	return q.ReserveUserVerificationEmail(ctx, db.ReserveUserVerificationEmailParams{
+		SentAt:       sentAt,
+		UserID:       ownerID,
+		ResendBefore: resendBefore,
+	})
+}
+
+// VerifyLearnerEmail marks a learner's email as verified using the emailed link
+func (h *LearnerHandler) VerifyLearnerEmail(c *gin.Context) {
+	loadEmail := func(ctx context.Context, ownerID pgtype.UUID) (string, error) {
+		user, err := h.store.GetUserById(ctx, ownerID)
+		return user.Email, err
+	}
+	markVerified := func(ctx context.Context, ownerID pgtype.UUID, verifiedAt pgtype.Timestamp) (int64, error) {
+		return h.store.MarkUserEmailVerified(ctx, db.MarkUserEmailVerifiedParams{
+			EmailVerifiedAt: verifiedAt,
+			UserID:          ownerID,
+		})
+	}
+	verifyEmail(c, h.tok, tokengen.RoleLearner, loadEmail, markVerified)
+}
+
+// ResendLearnerVerification emails a new verification link to an unverified learner
+func (h *LearnerHandler) ResendLearnerVerification(c *gin.Context) {
+	resendVerification(c, h.store, h.tok, h.mailer, h.config, tokengen.RoleLearner, h.learnerContact, reserveLearnerVerification, "/verify-email")
+}
+
+// ForgotLearnerPassword emails a password reset code to a learner
+func (h *LearnerHandler) ForgotLearnerPassword(c *gin.Context) {
+	requestPasswordReset(c, h.store, h.mailer, h.config, tokengen.RoleLearner, h.learnerContact, "/reset-password")
+}
+
+// ResetLearnerPassword sets a learner's new password using an emailed reset code
+func (h *LearnerHandler) ResetLearnerPassword(c *gin.Context) {
+	resetPassword(c, h.store, h.policy, tokengen.RoleLearner, func(ctx context.Context, q db.Querier, ownerID pgtype.UUID, hashedPassword string) error {
+		return q.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+			Password: hashedPassword,
+			UserID:   ownerID,
+		})
+	})
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/internal/middleware"
+	"lingo/pkg/auth/tokengen"
+	"lingo/utils"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UpdateLearnerProfileRequest only changes the fields that are sent.
+// An empty profile_image_url removes the avatar.
+type UpdateLearnerProfileRequest struct {
+	Username        *string `json:"username" binding:"omitempty,alphanum,min=3,max=50"`
+	Email           *string `json:"email" binding:"omitempty,email,max=100"`
+	ProfileImageUrl *string `json:"profile_image_url" binding:"omitempty,url,max=255"`
+}
+
+// learnerID returns the ID of the authenticated learner, responding with an error if it is missing
+func learnerID(c *gin.Context) (pgtype.UUID, bool) {
+	payload, err := middleware.GetPayload(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return pgtype.UUID{}, false
+	}
+	userID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		return pgtype.UUID{}, false
+	}
+	return userID, true
+}
+
+// GetMyProfile returns the authenticated learner's profile and enrolled courses
+func (h *LearnerHandler) GetMyProfile(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.store.GetUserById(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve profile"})
+		return
+	}
+
+	streakCount, err := currentStreak(c, h.store, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve profile"})
+		return
+	}
+
+	courses, err := h.store.ListUserCourses(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve enrolled courses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile retrieved successfully",
+		"user":    dto.NewLearner(user, streakCount),
+		"courses": dto.NewEnrolledCourses(courses),
+	})
+}
+
+// UpdateMyProfile changes the authenticated learner's username, email or avatar.
+// A new email has to be verified again.
+func (h *LearnerHandler) UpdateMyProfile(c *gin.Context) {
+	var req UpdateLearnerProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	current, err := h.store.GetUserById(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve profile"})
+		return
+	}
+
+	params := db.UpdateUserProfileParams{
+		Username:        current.Username,
+		Email:           current.Email,
+		ProfileImageUrl: current.ProfileImageUrl,
+		UserID:          userID,
+	}
+
+	if req.Username != nil && *req.Username != current.Username {
+		existing, err := h.store.GetUserByUsername(c, *req.Username)
+		if err == nil && existing.UserID != userID {
+			c.JSON(http.StatusConflict, gin.H{"error": "username already taken"})
+			return
+		}
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check username"})
+			return
+		}
+		params.Username = *req.Username
+	}
+
+	if req.Email != nil && *req.Email != current.Email {
+		existing, err := h.store.GetUserByEmail(c, *req.Email)
+		if err == nil && existing.UserID != userID {
+			c.JSON(http.StatusConflict, gin.H{"error": "email already in use"})
+			return
+		}
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check email"})
+			return
+		}
+		params.Email = *req.Email
+	}
+
+	if req.ProfileImageUrl != nil {
+		params.ProfileImageUrl = pgtype.Text{
+			String: *req.ProfileImageUrl,
+			Valid:  *req.ProfileImageUrl != "",
+		}
+	}
+
+	user, err := h.store.UpdateUserProfile(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update profile"})
+		return
+	}
+
+	if user.Email != current.Email {
+		contact := accountContact{ID: user.UserID, Name: user.Username, Email: user.Email}
+		_, err = sendVerificationEmail(c, h.store, h.tok, h.mailer, h.config, tokengen.RoleLearner, contact, reserveLearnerVerification, "/verify-email")
+		if err != nil {
+			log.Printf("failed to send verification email to learner %s: %v", user.UserID.String(), err)
+		}
+	}
+
+	streakCount, err := currentStreak(c, h.store, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile updated successfully",
+		"user":    dto.NewLearner(db.GetUserByIdRow(user), streakCount),
+	})
+}
+
+// GetLearnerProfile returns the public profile of any learner, without their email
+func (h *LearnerHandler) GetLearnerProfile(c *gin.Context) {
+	userID, err := utils.StringToPgTypeUUID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid learner ID format"})
+		return
+	}
+
+	user, err := h.store.GetUserById(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve profile"})
+		return
+	}
+
+	streakCount, err := currentStreak(c, h.store, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve profile"})
+		return
+	}
+
+	courses, err := h.store.ListUserCourses(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve enrolled courses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile retrieved successfully",
+		"user":    dto.NewPublicLearner(user, streakCount),
+		"courses": dto.NewEnrolledCourses(courses),
+	})
+}
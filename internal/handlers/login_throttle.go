@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/auth/tokengen"
+	"lingo/utils"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	throttleScopeAccount = "account"
+	throttleScopeIP      = "ip"
+
+	// Failures older than this are forgotten
+	loginFailureWindow = time.Hour
+	// Failed attempts allowed before backoff kicks in. IPs get more since
+	// many learners can share one behind a NAT.
+	accountFreeAttempts = 3
+	ipFreeAttempts      = 20
+	loginBackoffBase    = time.Second
+	maxLoginBackoff     = 5 * time.Minute
+	// After this many failures the account is locked and its owner emailed
+	accountLockoutThreshold = 10
+	accountLockoutDuration  = 30 * time.Minute
+)
+
+// accountThrottleKey identifies an admin or learner account by the email they log in with
+func accountThrottleKey(role tokengen.Role, email string) string {
+	return string(role) + ":" + strings.ToLower(email)
+}
+
+// loginBackoff is how long to block further attempts after the given number
+// of consecutive failures, doubling with every failure past the free ones
+func loginBackoff(failures, freeAttempts int) time.Duration {
+	if failures <= freeAttempts {
+		return 0
+	}
+	backoff := float64(loginBackoffBase) * math.Pow(2, float64(failures-freeAttempts-1))
+	if backoff > float64(maxLoginBackoff) {
+		return maxLoginBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// checkLoginThrottle returns how long the client has to wait before it may try
+// logging into the account again, or 0 if neither the account nor IP is locked
+func checkLoginThrottle(c *gin.Context, store *db.SQLStore, role tokengen.Role, email string) (time.Duration, error) {
+	now := time.Now().UTC()
+	var wait time.Duration
+
+	for _, key := range []db.GetLoginThrottleParams{
+		{Scope: throttleScopeAccount, ThrottleKey: accountThrottleKey(role, email)},
+		{Scope: throttleScopeIP, ThrottleKey: c.ClientIP()},
+	} {
+		throttle, err := store.GetLoginThrottle(c, key)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return 0, err
+		}
+		if throttle.LockedUntil.Valid && throttle.LockedUntil.Time.After(now) {
+			wait = max(wait, throttle.LockedUntil.Time.Sub(now))
+		}
+	}
+	return wait, nil
+}
+
+// recordLoginFailure counts a failed login against the account and IP and locks
+// them once they run out of free attempts. The owner is emailed when their account
+// gets locked out. Failures are recorded for unknown emails too, so responses
+// don't reveal which accounts exist.
+func recordLoginFailure(
+	c *gin.Context,
+	store *db.SQLStore,
+	mailer utils.EmailSender,
+	config utils.Config,
+	role tokengen.Role,
+	email string,
+	lookup func(ctx context.Context, email string) (accountContact, error),
+	forgotPath string,
+) error {
+	now := time.Now().UTC()
+
+	record := func(scope, key string) (int, error) {
+		throttle, err := store.RecordLoginFailure(c, db.RecordLoginFailureParams{
+			Scope:       scope,
+			ThrottleKey: key,
+			FailedAt:    pgtype.Timestamp{Time: now, Valid: true},
+			ResetBefore: pgtype.Timestamp{Time: now.Add(-loginFailureWindow), Valid: true},
+		})
+		return int(throttle.FailedCount), err
+	}
+	lock := func(scope, key string, duration time.Duration) error {
+		if duration == 0 {
+			return nil
+		}
+		return store.LockLogin(c, db.LockLoginParams{
+			LockedUntil: pgtype.Timestamp{Time: now.Add(duration), Valid: true},
+			Scope:       scope,
+			ThrottleKey: key,
+		})
+	}
+
+	ip := c.ClientIP()
+	ipFailures, err := record(throttleScopeIP, ip)
+	if err != nil {
+		return err
+	}
+	if err := lock(throttleScopeIP, ip, loginBackoff(ipFailures, ipFreeAttempts)); err != nil {
+		return err
+	}
+
+	accountKey := accountThrottleKey(role, email)
+	accountFailures, err := record(throttleScopeAccount, accountKey)
+	if err != nil {
+		return err
+	}
+	backoff := loginBackoff(accountFailures, accountFreeAttempts)
+	if accountFailures >= accountLockoutThreshold {
+		backoff = accountLockoutDuration
+	}
+	if err := lock(throttleScopeAccount, accountKey, backoff); err != nil {
+		return err
+	}
+
+	// Only email on the failure that locks the account, not on every one after it
+	if accountFailures != accountLockoutThreshold {
+		return nil
+	}
+	account, err := lookup(c, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	content, err := utils.RenderEmail("account_locked.html", map[string]any{
+		"Name":        account.Name,
+		"Attempts":    accountFailures,
+		"LockedUntil": now.Add(accountLockoutDuration).Format(time.RFC1123),
+		"ResetLink":   fmt.Sprintf("%s%s", config.ClientURL, forgotPath),
+	})
+	if err != nil {
+		return err
+	}
+	return mailer.SendEmail("Your Lingo account has been locked", content, []string{account.Email}, nil, nil, nil)
+}
+
+// clearLoginFailures forgets the failed logins of an account after a successful login.
+// The IP's failures are kept so logging into one account doesn't reset guesses at others.
+func clearLoginFailures(c *gin.Context, store *db.SQLStore, role tokengen.Role, email string) error {
+	_, err := store.DeleteLoginThrottle(c, db.DeleteLoginThrottleParams{
+		Scope:       throttleScopeAccount,
+		ThrottleKey: accountThrottleKey(role, email),
+	})
+	return err
+}
+
+// respondWithLoginThrottle rejects a login attempt that arrived while the account or IP is locked
+func respondWithLoginThrottle(c *gin.Context, wait time.Duration) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":       "too many failed login attempts, please try again later",
+		"retry_after": seconds,
+	})
+}
+
+// failLogin records a failed login and rejects it
+func failLogin(
+	c *gin.Context,
+	store *db.SQLStore,
+	mailer utils.EmailSender,
+	config utils.Config,
+	role tokengen.Role,
+	email string,
+	lookup func(ctx context.Context, email string) (accountContact, error),
+	forgotPath string,
+) {
+	if err := recordLoginFailure(c, store, mailer, config, role, email, lookup, forgotPath); err != nil {
+		log.Printf("failed to record failed %s login: %v", role, err)
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+}
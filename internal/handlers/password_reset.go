@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "lingo/internal/db/sqlc"
+	"lingo/pkg/auth/tokengen"
+	"lingo/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const passwordResetDuration = 30 * time.Minute
+
+var errInvalidPasswordReset = errors.New("reset code is invalid, expired or already used")
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email,max=100"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// accountContact is the admin or learner an emailed code or link is sent to
+type accountContact struct {
+	ID    pgtype.UUID
+	Name  string
+	Email string
+}
+
+// requestPasswordReset emails a single use reset code to the account with the given email.
+// The response is the same whether or not the account exists so emails can't be enumerated.
+func requestPasswordReset(
+	c *gin.Context,
+	store *db.SQLStore,
+	mailer utils.EmailSender,
+	config utils.Config,
+	role tokengen.Role,
+	lookup func(ctx context.Context, email string) (accountContact, error),
+	linkPath string,
+) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sent := gin.H{"message": "if an account exists for this email, a reset code has been sent"}
+
+	account, err := lookup(c, req.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusOK, sent)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process request"})
+		return
+	}
+
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create reset code"})
+		return
+	}
+	expiresAt := time.Now().UTC().Add(passwordResetDuration)
+
+	content, err := utils.RenderEmail("password_reset.html", map[string]any{
+		"Name":      account.Name,
+		"Link":      fmt.Sprintf("%s%s?token=%s", config.ClientURL, linkPath, token),
+		"Code":      token,
+		"ExpiresAt": expiresAt.Format(time.RFC1123),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create reset code"})
+		return
+	}
+
+	err = store.ExecTx(c, func(q db.Querier) error {
+		// Only the latest code can be redeemed
+		err := q.InvalidatePasswordResets(c, db.InvalidatePasswordResetsParams{
+			OwnerID:   account.ID,
+			OwnerRole: string(role),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePasswordReset(c, db.CreatePasswordResetParams{
+			OwnerID:   account.ID,
+			OwnerRole: string(role),
+			TokenHash: utils.HashToken(token),
+			ExpiresAt: pgtype.Timestamp{Time: expiresAt, Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create reset code"})
+		return
+	}
+
+	// The code is saved first and the email sent once the transaction has committed,
+	// so no connection is held open during delivery. A failed send is only logged:
+	// answering differently would reveal that the account exists. The owner can
+	// request another code.
+	if err := mailer.SendEmail("Reset your Lingo password", content, []string{account.Email}, nil, nil, nil); err != nil {
+		log.Printf("failed to send password reset email to %s %s: %v", role, account.ID.String(), err)
+	}
+
+	c.JSON(http.StatusOK, sent)
+}
+
+// resetPassword redeems a reset code, sets the new password and logs the
+// account out of every device
+func resetPassword(
+	c *gin.Context,
+	store *db.SQLStore,
+	policy *utils.PasswordPolicy,
+	role tokengen.Role,
+	setPassword func(ctx context.Context, q db.Querier, ownerID pgtype.UUID, hashedPassword string) error,
+) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := policy.Validate(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process password"})
+		return
+	}
+
+	err = store.ExecTx(c, func(q db.Querier) error {
+		reset, err := q.GetPasswordResetForUpdate(c, utils.HashToken(req.Token))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errInvalidPasswordReset
+			}
+			return err
+		}
+		if reset.OwnerRole != string(role) || reset.UsedAt.Valid || time.Now().UTC().After(reset.ExpiresAt.Time) {
+			return errInvalidPasswordReset
+		}
+
+		if err := setPassword(c, q, reset.OwnerID, hashedPassword); err != nil {
+			return err
+		}
+
+		err = q.InvalidatePasswordResets(c, db.InvalidatePasswordResetsParams{
+			OwnerID:   reset.OwnerID,
+			OwnerRole: reset.OwnerRole,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.RevokeSessionsByOwner(c, db.RevokeSessionsByOwnerParams{
+			OwnerID:   reset.OwnerID,
+			OwnerRole: reset.OwnerRole,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, errInvalidPasswordReset) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reset password"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password reset successfully, please log in again"})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/pkg/srs"
+	"lingo/utils"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultReviewSessionSize = 20
+	maxReviewSessionSize     = 50
+)
+
+// ReviewHandler builds spaced repetition review sessions from exercises learners have completed
+type ReviewHandler struct {
+	store *db.SQLStore
+}
+
+func NewReviewHandler(store *db.SQLStore) *ReviewHandler {
+	return &ReviewHandler{
+		store: store,
+	}
+}
+
+// scheduleReview updates an exercise's review schedule from a graded answer.
+// Exercises join the review queue once completed, and answers given before a
+// review is due leave the schedule as it is. It should run in the same
+// transaction that records the attempt.
+func scheduleReview(ctx context.Context, q db.Querier, userID, exerciseID pgtype.UUID, completed bool, score int, now time.Time) (*db.ReviewItem, error) {
+	item, err := q.GetReviewItemForUpdate(ctx, db.GetReviewItemForUpdateParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+
+	var it srs.Item
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if !completed {
+			return nil, nil
+		}
+		it = srs.New()
+	case err != nil:
+		return nil, err
+	case item.DueAt.Time.After(now):
+		return &item, nil
+	default:
+		it = srs.Item{
+			Ease:        item.EaseFactor,
+			Interval:    item.IntervalDays,
+			Repetitions: item.Repetitions,
+			Due:         item.DueAt.Time,
+		}
+	}
+
+	it = srs.Review(it, srs.Quality(score), now)
+	item, err = q.UpsertReviewItem(ctx, db.UpsertReviewItemParams{
+		UserID:         userID,
+		ExerciseID:     exerciseID,
+		EaseFactor:     it.Ease,
+		IntervalDays:   it.Interval,
+		Repetitions:    it.Repetitions,
+		DueAt:          pgtype.Timestamp{Time: it.Due, Valid: true},
+		LastReviewedAt: pgtype.Timestamp{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetDueReviews assembles a review session from the most overdue exercises across
+// the learner's enrolled courses in the language_id language, shuffled so that
+// courses, lessons and exercise types are mixed. Answers are submitted like any
+// other exercise.
+func (h *ReviewHandler) GetDueReviews(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	languageID, err := utils.StringToPgTypeUUID(c.Query("language_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language_id parameter"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultReviewSessionSize)))
+	if err != nil || limit < 1 || limit > maxReviewSessionSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	now := pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+	due, err := h.store.ListDueReviews(c, db.ListDueReviewsParams{
+		UserID:     userID,
+		LanguageID: languageID,
+		Now:        now,
+		MaxItems:   int32(limit),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve due reviews"})
+		return
+	}
+
+	count, err := h.store.CountDueReviews(c, db.CountDueReviewsParams{
+		UserID:     userID,
+		LanguageID: languageID,
+		Now:        now,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve due reviews"})
+		return
+	}
+
+	rand.Shuffle(len(due), func(i, j int) {
+		due[i], due[j] = due[j], due[i]
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Review session retrieved successfully",
+		"due_count": count,
+		"exercises": dto.NewReviewExercises(due),
+	})
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/middleware"
+	"lingo/pkg/auth/tokengen"
+	"lingo/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	accessTokenDuration  = 30 * time.Minute
+	refreshTokenDuration = 7 * 24 * time.Hour
+	maxUserAgentLength   = 255
+)
+
+var (
+	errInvalidRefreshToken = errors.New("invalid refresh token")
+	errRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// startSession issues an access/refresh token pair for a fresh login,
+// starting a new refresh token family
+func startSession(c *gin.Context, q db.Querier, tok tokengen.Maker, ownerID pgtype.UUID, role tokengen.Role) (string, string, error) {
+	familyID, err := uuid.NewRandom()
+	if err != nil {
+		return "", "", err
+	}
+	return issueTokens(c, q, tok, ownerID, role, utils.UUIDToPgType(familyID))
+}
+
+// issueTokens creates an access/refresh token pair and stores the hashed
+// refresh token as a session belonging to the given family
+func issueTokens(c *gin.Context, q db.Querier, tok tokengen.Maker, ownerID pgtype.UUID, role tokengen.Role, familyID pgtype.UUID) (string, string, error) {
+	accessToken, _, err := tok.CreateToken(ownerID, role, accessTokenDuration)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, refreshPayload, err := tok.CreateRefreshToken(ownerID, role, refreshTokenDuration)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The column holds maxUserAgentLength characters of valid UTF-8, whatever the client sent
+	userAgent := []rune(strings.ToValidUTF8(c.Request.UserAgent(), ""))
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	_, err = q.CreateSession(c, db.CreateSessionParams{
+		SessionID:        utils.UUIDToPgType(refreshPayload.ID),
+		FamilyID:         familyID,
+		OwnerID:          ownerID,
+		OwnerRole:        string(role),
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		UserAgent:        string(userAgent),
+		ClientIp:         c.ClientIP(),
+		ExpiresAt:        pgtype.Timestamp{Time: refreshPayload.ExpiredAt, Valid: true},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// lookupSession verifies a refresh token and loads the session it was issued for
+func lookupSession(c *gin.Context, store *db.SQLStore, tok tokengen.Maker, refreshToken string, role tokengen.Role) (db.Session, error) {
+	payload, err := tok.VerifyRefreshToken(refreshToken)
+	if err != nil {
+		return db.Session{}, err
+	}
+	if payload.Role != role {
+		return db.Session{}, errInvalidRefreshToken
+	}
+
+	session, err := store.GetSession(c, utils.UUIDToPgType(payload.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Session{}, errInvalidRefreshToken
+		}
+		return db.Session{}, err
+	}
+
+	if session.OwnerRole != string(role) || session.RefreshTokenHash != utils.HashToken(refreshToken) {
+		return db.Session{}, errInvalidRefreshToken
+	}
+	return session, nil
+}
+
+// rotateSession exchanges a refresh token for a new token pair in the same family.
+// Presenting a refresh token that was already rotated revokes the whole family,
+// since either the legitimate client or an attacker is holding a stolen copy.
+func rotateSession(c *gin.Context, store *db.SQLStore, tok tokengen.Maker, refreshToken string, role tokengen.Role) (string, string, error) {
+	session, err := lookupSession(c, store, tok, refreshToken, role)
+	if err != nil {
+		return "", "", err
+	}
+	if session.IsRevoked {
+		return "", "", errInvalidRefreshToken
+	}
+
+	var accessToken, newRefreshToken string
+	if !session.RotatedAt.Valid {
+		err = store.ExecTx(c, func(q db.Querier) error {
+			rows, err := q.MarkSessionRotated(c, session.SessionID)
+			if err != nil {
+				return err
+			}
+			// Another request rotated the session between our read and this update
+			if rows == 0 {
+				return errRefreshTokenReused
+			}
+
+			accessToken, newRefreshToken, err = issueTokens(c, q, tok, session.OwnerID, role, session.FamilyID)
+			return err
+		})
+	} else {
+		err = errRefreshTokenReused
+	}
+
+	if errors.Is(err, errRefreshTokenReused) {
+		if revokeErr := store.RevokeSessionFamily(c, session.FamilyID); revokeErr != nil {
+			return "", "", revokeErr
+		}
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, newRefreshToken, nil
+}
+
+// respondWithSessionError maps refresh token errors to a response
+func respondWithSessionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tokengen.ErrExpiredToken):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh token has expired"})
+	case errors.Is(err, errRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh token has already been used, please log in again"})
+	case errors.Is(err, errInvalidRefreshToken),
+		errors.Is(err, tokengen.ErrInvalidToken),
+		errors.Is(err, tokengen.ErrInvalidTokenType):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process refresh token"})
+	}
+}
+
+// refreshSession handles a refresh request for the given role
+func refreshSession(c *gin.Context, store *db.SQLStore, tok tokengen.Maker, role tokengen.Role) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accessToken, refreshToken, err := rotateSession(c, store, tok, req.RefreshToken, role)
+	if err != nil {
+		respondWithSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "token refreshed",
+		"token":         accessToken,
+		"refresh_token": refreshToken,
+	})
+}
+
+// logoutSession revokes the refresh token family of the presented refresh token,
+// logging out the device it was issued to
+func logoutSession(c *gin.Context, store *db.SQLStore, tok tokengen.Maker, role tokengen.Role) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := lookupSession(c, store, tok, req.RefreshToken, role)
+	if err != nil {
+		respondWithSessionError(c, err)
+		return
+	}
+
+	if err := store.RevokeSessionFamily(c, session.FamilyID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+// logoutAllSessions revokes every session of the authenticated admin or learner
+func logoutAllSessions(c *gin.Context, store *db.SQLStore, role tokengen.Role) {
+	payload, err := middleware.GetPayload(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	ownerID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		return
+	}
+
+	err = store.RevokeSessionsByOwner(c, db.RevokeSessionsByOwnerParams{
+		OwnerID:   ownerID,
+		OwnerRole: string(role),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log out of all devices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out of all devices"})
+}
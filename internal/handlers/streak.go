@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/pkg/streak"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	streakStatusActive = "active"
+	streakStatusFrozen = "frozen"
+	// defaultStreakHistoryDays fills a five week calendar
+	defaultStreakHistoryDays = 35
+	maxStreakHistoryDays     = 366
+	dateLayout               = "2006-01-02"
+)
+
+// StreakHandler shows learners their daily streak
+type StreakHandler struct {
+	store *db.SQLStore
+}
+
+func NewStreakHandler(store *db.SQLStore) *StreakHandler {
+	return &StreakHandler{
+		store: store,
+	}
+}
+
+type UpdateTimezoneRequest struct {
+	Timezone string `json:"timezone" binding:"required,max=64"`
+}
+
+// learnerLocation loads a learner's timezone, falling back to UTC if it has become invalid
+func learnerLocation(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Printf("failed to load timezone %q: %v", timezone, err)
+		return time.UTC
+	}
+	return loc
+}
+
+func streakState(count pgtype.Int4, longest, freezes int32, lastDay pgtype.Date) streak.State {
+	state := streak.State{Count: count.Int32, Longest: longest, Freezes: freezes}
+	if lastDay.Valid {
+		state.LastDay = lastDay.Time
+	}
+	return state
+}
+
+// currentStreak is the learner's streak as of their current local day. The stored
+// streak_count is only updated on activity, so it can be stale after missed days.
+func currentStreak(ctx context.Context, q db.Querier, userID pgtype.UUID) (int32, error) {
+	row, err := q.GetUserStreak(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	today := streak.Day(time.Now().UTC(), learnerLocation(row.Timezone))
+	return streak.Current(streakState(row.StreakCount, row.LongestStreak, row.StreakFreezes, row.LastStreakDate), today), nil
+}
+
+// recordStreakActivity extends the learner's streak for a qualifying activity at now.
+// It should run in the same transaction that records the activity.
+func recordStreakActivity(ctx context.Context, q db.Querier, userID pgtype.UUID, now time.Time) (dto.Streak, error) {
+	row, err := q.GetUserStreakForUpdate(ctx, userID)
+	if err != nil {
+		return dto.Streak{}, err
+	}
+
+	today := streak.Day(now, learnerLocation(row.Timezone))
+	state, update := streak.Record(streakState(row.StreakCount, row.LongestStreak, row.StreakFreezes, row.LastStreakDate), today)
+	if !update.Extended {
+		return dto.NewStreak(state, row.Timezone, today), nil
+	}
+
+	for _, day := range update.FrozenDays {
+		err = q.RecordStreakDay(ctx, db.RecordStreakDayParams{
+			UserID:     userID,
+			StreakDate: pgtype.Date{Time: day, Valid: true},
+			Status:     streakStatusFrozen,
+		})
+		if err != nil {
+			return dto.Streak{}, err
+		}
+	}
+
+	err = q.RecordStreakDay(ctx, db.RecordStreakDayParams{
+		UserID:     userID,
+		StreakDate: pgtype.Date{Time: today, Valid: true},
+		Status:     streakStatusActive,
+	})
+	if err != nil {
+		return dto.Streak{}, err
+	}
+
+	err = q.UpdateUserStreak(ctx, db.UpdateUserStreakParams{
+		StreakCount:    pgtype.Int4{Int32: state.Count, Valid: true},
+		LongestStreak:  state.Longest,
+		StreakFreezes:  state.Freezes,
+		LastStreakDate: pgtype.Date{Time: state.LastDay, Valid: true},
+		LastActiveDate: pgtype.Timestamp{Time: now, Valid: true},
+		UserID:         userID,
+	})
+	if err != nil {
+		return dto.Streak{}, err
+	}
+	return dto.NewStreak(state, row.Timezone, today), nil
+}
+
+// GetMyStreak returns the authenticated learner's current streak and streak freezes
+func (h *StreakHandler) GetMyStreak(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	row, err := h.store.GetUserStreak(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve streak"})
+		return
+	}
+
+	today := streak.Day(time.Now().UTC(), learnerLocation(row.Timezone))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Streak retrieved successfully",
+		"streak":  dto.NewStreak(streakState(row.StreakCount, row.LongestStreak, row.StreakFreezes, row.LastStreakDate), row.Timezone, today),
+	})
+}
+
+// GetStreakHistory returns the active and frozen days between the from and to
+// dates (YYYY-MM-DD) for a calendar view. It defaults to the last five weeks.
+func (h *StreakHandler) GetStreakHistory(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	row, err := h.store.GetUserStreak(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve streak"})
+		return
+	}
+
+	to := streak.Day(time.Now().UTC(), learnerLocation(row.Timezone))
+	if s := c.Query("to"); s != "" {
+		if to, err = time.Parse(dateLayout, s); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to parameter, expected YYYY-MM-DD"})
+			return
+		}
+	}
+	from := to.AddDate(0, 0, 1-defaultStreakHistoryDays)
+	if s := c.Query("from"); s != "" {
+		if from, err = time.Parse(dateLayout, s); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from parameter, expected YYYY-MM-DD"})
+			return
+		}
+	}
+	if from.After(to) || to.Sub(from) >= maxStreakHistoryDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to and at most a year earlier"})
+		return
+	}
+
+	days, err := h.store.ListStreakDays(c, db.ListStreakDaysParams{
+		UserID:   userID,
+		FromDate: pgtype.Date{Time: from, Valid: true},
+		ToDate:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve streak history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Streak history retrieved successfully",
+		"from":    from.Format(dateLayout),
+		"to":      to.Format(dateLayout),
+		"days":    dto.NewStreakDays(days),
+	})
+}
+
+// UpdateTimezone sets the IANA timezone, such as Africa/Lagos, that the
+// authenticated learner's streak days are counted in
+func (h *StreakHandler) UpdateTimezone(c *gin.Context) {
+	var req UpdateTimezoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	// "Local" would follow the server's timezone rather than the learner's
+	if _, err := time.LoadLocation(req.Timezone); err != nil || req.Timezone == "Local" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+		return
+	}
+
+	err := h.store.SetUserTimezone(c, db.SetUserTimezoneParams{
+		Timezone: req.Timezone,
+		UserID:   userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update timezone"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Timezone updated successfully",
+		"timezone": req.Timezone,
+	})
+}
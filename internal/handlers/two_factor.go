@@ -0,0 +1,453 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/middleware"
+	"lingo/pkg/auth/rbac"
+	"lingo/pkg/auth/tokengen"
+	"lingo/pkg/auth/totp"
+	"lingo/utils"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	twoFactorTokenDuration = 5 * time.Minute
+	// Accept codes from one period either side of now to allow for clock drift
+	totpSkew          = 1
+	totpIssuer        = "Lingo"
+	recoveryCodeCount = 10
+)
+
+var (
+	errTwoFactorEnabled    = errors.New("two-factor authentication is already enabled")
+	errTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+	errInvalidTwoFactor    = errors.New("invalid two-factor code")
+)
+
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+type TwoFactorLoginRequest struct {
+	TwoFactorToken string `json:"two_factor_token" binding:"required"`
+	Code           string `json:"code" binding:"required,max=32"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required,max=32"`
+}
+
+// normalizeRecoveryCode lets recovery codes be typed with or without dashes and in any case
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// newRecoveryCodes replaces every recovery code of the admin with a fresh set
+// and returns them. Only their hashes are stored, so they can't be shown again.
+func newRecoveryCodes(ctx context.Context, q db.Querier, adminID pgtype.UUID) ([]string, error) {
+	if err := q.DeleteAdminRecoveryCodes(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := recoveryCodeEncoding.EncodeToString(b)[:10]
+
+		err := q.CreateAdminRecoveryCode(ctx, db.CreateAdminRecoveryCodeParams{
+			AdminID:  adminID,
+			CodeHash: utils.HashToken(code),
+		})
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes, nil
+}
+
+// verifyTwoFactorCode accepts a current authenticator code or an unused recovery
+// code, using it up so it can't be presented again
+func verifyTwoFactorCode(ctx context.Context, q db.Querier, admin db.GetAdminTwoFactorRow, code string) (bool, error) {
+	if !admin.TotpEnabledAt.Valid || !admin.TotpSecret.Valid {
+		return false, nil
+	}
+	code = strings.TrimSpace(code)
+	now := time.Now().UTC()
+
+	step, ok, err := totp.Validate(admin.TotpSecret.String, code, now, totpSkew)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		rows, err := q.UseAdminTotpStep(ctx, db.UseAdminTotpStepParams{
+			TotpLastStep: pgtype.Int8{Int64: step, Valid: true},
+			AdminID:      admin.AdminID,
+		})
+		return rows == 1, err
+	}
+
+	rows, err := q.UseAdminRecoveryCode(ctx, db.UseAdminRecoveryCodeParams{
+		UsedAt:   pgtype.Timestamp{Time: now, Valid: true},
+		AdminID:  admin.AdminID,
+		CodeHash: utils.HashToken(normalizeRecoveryCode(code)),
+	})
+	return rows == 1, err
+}
+
+// loadTwoFactor fetches the two-factor settings of the authenticated admin,
+// responding with an error if they can't be loaded
+func (h *AdminHandler) loadTwoFactor(c *gin.Context) (db.GetAdminTwoFactorRow, bool) {
+	payload, err := middleware.GetPayload(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return db.GetAdminTwoFactorRow{}, false
+	}
+	adminID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		return db.GetAdminTwoFactorRow{}, false
+	}
+
+	admin, err := h.store.GetAdminTwoFactor(c, adminID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "admin account no longer exists"})
+			return db.GetAdminTwoFactorRow{}, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load two-factor settings"})
+		return db.GetAdminTwoFactorRow{}, false
+	}
+	return admin, true
+}
+
+// startTwoFactorChallenge responds to a correct password with a short lived token
+// that has to be exchanged along with a two-factor code for the real tokens
+func (h *AdminHandler) startTwoFactorChallenge(c *gin.Context, adminID pgtype.UUID) {
+	token, _, err := h.tok.CreateTwoFactorToken(adminID, tokengen.RoleAdmin, twoFactorTokenDuration)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":             "two-factor authentication required",
+		"two_factor_required": true,
+		"two_factor_token":    token,
+	})
+}
+
+// VerifyAdminTwoFactorLogin completes an admin login with a two-factor code
+func (h *AdminHandler) VerifyAdminTwoFactorLogin(c *gin.Context) {
+	var req TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payload, err := h.tok.VerifyTwoFactorToken(req.TwoFactorToken)
+	if err != nil || payload.Role != tokengen.RoleAdmin {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "two-factor login has expired, please log in again"})
+		return
+	}
+	adminID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		return
+	}
+
+	admin, err := h.store.GetAdminTwoFactor(c, adminID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "admin account no longer exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+
+	// Codes are guessable too, so they count towards the same lockout as passwords
+	wait, err := checkLoginThrottle(c, h.store, tokengen.RoleAdmin, admin.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+	if wait > 0 {
+		respondWithLoginThrottle(c, wait)
+		return
+	}
+
+	ok, err := verifyTwoFactorCode(c, h.store, admin, req.Code)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process login"})
+		return
+	}
+	if !ok {
+		if err := recordLoginFailure(c, h.store, h.mailer, h.config, tokengen.RoleAdmin, admin.Email, h.adminContact, "/admin/forgot-password"); err != nil {
+			log.Printf("failed to record failed admin login: %v", err)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errInvalidTwoFactor.Error()})
+		return
+	}
+	if err := clearLoginFailures(c, h.store, tokengen.RoleAdmin, admin.Email); err != nil {
+		log.Printf("failed to clear failed logins: %v", err)
+	}
+
+	token, refreshToken, err := startSession(c, h.store, h.tok, admin.AdminID, tokengen.RoleAdmin)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "login successful",
+		"token":         token,
+		"refresh_token": refreshToken,
+	})
+}
+
+// GetTwoFactorStatus reports whether the authenticated admin has two-factor
+// authentication enabled and whether their role requires it
+func (h *AdminHandler) GetTwoFactorStatus(c *gin.Context) {
+	admin, ok := h.loadTwoFactor(c)
+	if !ok {
+		return
+	}
+
+	remaining, err := h.store.CountAdminRecoveryCodes(c, admin.AdminID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load two-factor settings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":                  "Two-factor status retrieved successfully",
+		"enabled":                  admin.TotpEnabledAt.Valid,
+		"required":                 h.twoFactor.Requires(rbac.Role(admin.Role)),
+		"recovery_codes_remaining": remaining,
+	})
+}
+
+// EnrollTwoFactor generates a new authenticator secret for the authenticated admin.
+// It isn't enforced until confirmed with a code from the authenticator app.
+func (h *AdminHandler) EnrollTwoFactor(c *gin.Context) {
+	admin, ok := h.loadTwoFactor(c)
+	if !ok {
+		return
+	}
+	if admin.TotpEnabledAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": errTwoFactorEnabled.Error()})
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate secret"})
+		return
+	}
+
+	rows, err := h.store.SetAdminTotpSecret(c, db.SetAdminTotpSecretParams{
+		TotpSecret: pgtype.Text{String: secret, Valid: true},
+		AdminID:    admin.AdminID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save secret"})
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": errTwoFactorEnabled.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Scan the QR code with your authenticator app, then confirm with a code",
+		"secret":      secret,
+		"otpauth_uri": totp.URI(totpIssuer, admin.Email, secret),
+	})
+}
+
+// ConfirmTwoFactor enables two-factor authentication once the admin proves their
+// authenticator app works, and returns their recovery codes
+func (h *AdminHandler) ConfirmTwoFactor(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	admin, ok := h.loadTwoFactor(c)
+	if !ok {
+		return
+	}
+	if admin.TotpEnabledAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": errTwoFactorEnabled.Error()})
+		return
+	}
+	if !admin.TotpSecret.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Enroll in two-factor authentication first"})
+		return
+	}
+
+	step, ok, err := totp.Validate(admin.TotpSecret.String, strings.TrimSpace(req.Code), time.Now().UTC(), totpSkew)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify code"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidTwoFactor.Error()})
+		return
+	}
+
+	var codes []string
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		rows, err := q.EnableAdminTotp(c, db.EnableAdminTotpParams{
+			TotpEnabledAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+			TotpLastStep:  pgtype.Int8{Int64: step, Valid: true},
+			AdminID:       admin.AdminID,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return errTwoFactorEnabled
+		}
+
+		codes, err = newRecoveryCodes(c, q, admin.AdminID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errTwoFactorEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Two-factor authentication enabled, store these recovery codes somewhere safe",
+		"recovery_codes": codes,
+	})
+}
+
+// RegenerateRecoveryCodes replaces the authenticated admin's recovery codes
+func (h *AdminHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	admin, ok := h.loadTwoFactor(c)
+	if !ok {
+		return
+	}
+	if !admin.TotpEnabledAt.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errTwoFactorNotEnabled.Error()})
+		return
+	}
+
+	var codes []string
+	err := h.store.ExecTx(c, func(q db.Querier) error {
+		ok, err := verifyTwoFactorCode(c, q, admin, req.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errInvalidTwoFactor
+		}
+
+		codes, err = newRecoveryCodes(c, q, admin.AdminID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errInvalidTwoFactor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to regenerate recovery codes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Recovery codes regenerated, the old ones no longer work",
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactor turns off two-factor authentication for the authenticated admin,
+// unless their role requires it
+func (h *AdminHandler) DisableTwoFactor(c *gin.Context) {
+	var req DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	admin, ok := h.loadTwoFactor(c)
+	if !ok {
+		return
+	}
+	if h.twoFactor.Requires(rbac.Role(admin.Role)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "your role requires two-factor authentication"})
+		return
+	}
+	if !admin.TotpEnabledAt.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errTwoFactorNotEnabled.Error()})
+		return
+	}
+
+	credentials, err := h.store.GetAdminCredentials(c, admin.AdminID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve admin"})
+		return
+	}
+	if !utils.CompareHashAndPassword(credentials.Password, req.Password) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Password is incorrect"})
+		return
+	}
+
+	err = h.store.ExecTx(c, func(q db.Querier) error {
+		ok, err := verifyTwoFactorCode(c, q, admin, req.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errInvalidTwoFactor
+		}
+
+		if err := q.DisableAdminTotp(c, admin.AdminID); err != nil {
+			return err
+		}
+		return q.DeleteAdminRecoveryCodes(c, admin.AdminID)
+	})
+	if err != nil {
+		if errors.Is(err, errInvalidTwoFactor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Two-factor authentication disabled",
+	})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VocabularyHandler serves the words a learner has picked up from completed exercises
+type VocabularyHandler struct {
+	store *db.SQLStore
+}
+
+func NewVocabularyHandler(store *db.SQLStore) *VocabularyHandler {
+	return &VocabularyHandler{
+		store: store,
+	}
+}
+
+// GetLearnedWords lists the words in the language_id language that the learner
+// has met in a completed exercise, most recently learned first, alongside how
+// many words the language has in total.
+func (h *VocabularyHandler) GetLearnedWords(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	languageID, err := utils.StringToPgTypeUUID(c.Query("language_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language_id parameter"})
+		return
+	}
+
+	words, err := h.store.ListLearnedVocabulary(c, db.ListLearnedVocabularyParams{
+		UserID:     userID,
+		LanguageID: languageID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve learned words"})
+		return
+	}
+
+	total, err := h.store.CountVocabularyByLanguage(c, languageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve learned words"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Learned words retrieved successfully",
+		"words_learned": len(words),
+		"total_words":   total,
+		"words":         dto.NewLearnedWords(words),
+	})
+}
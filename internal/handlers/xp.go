@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	db "lingo/internal/db/sqlc"
+	"lingo/internal/dto"
+	"lingo/pkg/streak"
+	"lingo/pkg/xp"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultXPHistoryDays  = 30
+	defaultXPHistoryWeeks = 12
+	maxXPHistoryPeriods   = 366
+)
+
+// XPHandler shows learners the XP they have earned
+type XPHandler struct {
+	store *db.SQLStore
+}
+
+func NewXPHandler(store *db.SQLStore) *XPHandler {
+	return &XPHandler{
+		store: store,
+	}
+}
+
+// awardXP appends an award to the XP ledger and adds it to the learner's XP
+// points and weekly league. An award already in the ledger is skipped and
+// reported as not awarded.
+func awardXP(ctx context.Context, q db.Querier, userID, lessonID pgtype.UUID, award xp.Award, now time.Time) (db.XpLedger, bool, error) {
+	entry, err := q.CreateXPAward(ctx, db.CreateXPAwardParams{
+		UserID:         userID,
+		Source:         award.Source,
+		Amount:         award.Amount,
+		IdempotencyKey: award.Key,
+		LessonID:       lessonID,
+		AwardedAt:      pgtype.Timestamp{Time: now, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.XpLedger{}, false, nil
+		}
+		return db.XpLedger{}, false, err
+	}
+
+	_, err = q.AddUserXP(ctx, db.AddUserXPParams{
+		Amount: pgtype.Int4{Int32: award.Amount, Valid: true},
+		UserID: userID,
+	})
+	if err != nil {
+		return db.XpLedger{}, false, err
+	}
+
+	if err := recordLeagueXP(ctx, q, userID, award.Amount, now); err != nil {
+		return db.XpLedger{}, false, err
+	}
+	return entry, true, nil
+}
+
+// recordLessonXP awards the XP earned so far on a lesson after an exercise in it is passed.
+// It should run in the same transaction that records the exercise attempt.
+func recordLessonXP(ctx context.Context, q db.Querier, userID, lessonID pgtype.UUID, now time.Time) ([]db.XpLedger, error) {
+	row, err := q.GetLessonProgress(ctx, db.GetLessonProgressParams{
+		UserID:   userID,
+		LessonID: lessonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	progress := xp.LessonProgress{
+		Exercises: row.TotalExercises,
+		Completed: row.CompletedExercises,
+		Perfect:   row.PerfectExercises,
+	}
+	if row.XpReward.Valid {
+		progress.Reward = &row.XpReward.Int32
+	}
+
+	var entries []db.XpLedger
+	for _, award := range xp.ForLesson(lessonID.String(), progress) {
+		entry, awarded, err := awardXP(ctx, q, userID, lessonID, award, now)
+		if err != nil {
+			return nil, err
+		}
+		if awarded {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// recordStreakXP awards the streak bonus if the learner's streak has reached a milestone today
+func recordStreakXP(ctx context.Context, q db.Querier, userID pgtype.UUID, s dto.Streak, now time.Time) ([]db.XpLedger, error) {
+	if !s.ExtendedToday || s.LastStreakDate == nil {
+		return nil, nil
+	}
+	day, err := time.Parse(dateLayout, *s.LastStreakDate)
+	if err != nil {
+		return nil, err
+	}
+
+	award, ok := xp.ForStreak(s.CurrentStreak, day)
+	if !ok {
+		return nil, nil
+	}
+	entry, awarded, err := awardXP(ctx, q, userID, pgtype.UUID{}, award, now)
+	if err != nil || !awarded {
+		return nil, err
+	}
+	return []db.XpLedger{entry}, nil
+}
+
+// GetXPHistory returns the XP the authenticated learner earned per day or week
+// in their timezone, most recent first. period is day (the default) or week,
+// and count is how many periods to return.
+func (h *XPHandler) GetXPHistory(c *gin.Context) {
+	userID, ok := learnerID(c)
+	if !ok {
+		return
+	}
+
+	period := c.DefaultQuery("period", xp.PeriodDay)
+	if !xp.ValidPeriod(period) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period parameter, expected day or week"})
+		return
+	}
+
+	count := defaultXPHistoryDays
+	if period == xp.PeriodWeek {
+		count = defaultXPHistoryWeeks
+	}
+	if s := c.Query("count"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxXPHistoryPeriods {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count parameter"})
+			return
+		}
+		count = n
+	}
+
+	row, err := h.store.GetUserStreak(c, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "learner not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve learner"})
+		return
+	}
+
+	loc := learnerLocation(row.Timezone)
+	starts := xp.Periods(streak.Day(time.Now().UTC(), loc), period, count)
+	first := starts[len(starts)-1]
+	since := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc).UTC()
+
+	rows, err := h.store.ListXPHistory(c, db.ListXPHistoryParams{
+		Period:   period,
+		Timezone: loc.String(),
+		UserID:   userID,
+		Since:    pgtype.Timestamp{Time: since, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve XP history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "XP history retrieved successfully",
+		"period":  period,
+		"history": dto.NewXPHistory(starts, rows),
+	})
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"errors"
+	"lingo/pkg/auth/tokengen"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeaderKey  = "Authorization"
+	authorizationTypeBearer = "bearer"
+	// AuthorizationPayloadKey is the Gin context key the verified token payload is stored under
+	AuthorizationPayloadKey = "authorization_payload"
+)
+
+// AuthMiddleware rejects requests without a valid bearer access token issued
+// for the given role and stores the verified token payload in the Gin context
+func AuthMiddleware(tok tokengen.Maker, role tokengen.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader(authorizationHeaderKey)
+		if authHeader == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization header is not provided"})
+			return
+		}
+
+		fields := strings.Fields(authHeader)
+		if len(fields) != 2 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+			return
+		}
+
+		if strings.ToLower(fields[0]) != authorizationTypeBearer {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unsupported authorization type"})
+			return
+		}
+
+		payload, err := tok.VerifyToken(fields[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": tokenErrorMessage(err)})
+			return
+		}
+
+		if payload.Role != role {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token is not valid for this resource"})
+			return
+		}
+
+		c.Set(AuthorizationPayloadKey, payload)
+		c.Next()
+	}
+}
+
+// tokenErrorMessage turns a token verification error into a client-facing reason
+func tokenErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, tokengen.ErrExpiredToken):
+		return "token has expired"
+	case errors.Is(err, tokengen.ErrInvalidTokenType):
+		return "token type is not valid for this resource"
+	default:
+		return "invalid token"
+	}
+}
+
+// GetPayload returns the token payload stored by AuthMiddleware
+func GetPayload(c *gin.Context) (*tokengen.Payload, error) {
+	value, exists := c.Get(AuthorizationPayloadKey)
+	if !exists {
+		return nil, errors.New("authorization payload not found")
+	}
+
+	payload, ok := value.(*tokengen.Payload)
+	if !ok {
+		return nil, errors.New("authorization payload has an unexpected type")
+	}
+	return payload, nil
+}
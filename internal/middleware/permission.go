@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"lingo/pkg/auth/rbac"
+	"lingo/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AdminRoleKey is the Gin context key the caller's admin role is stored under
+const AdminRoleKey = "admin_role"
+
+// AdminRoleGetter looks up an admin's current role
+type AdminRoleGetter interface {
+	GetAdminRole(ctx context.Context, adminID pgtype.UUID) (string, error)
+}
+
+// RequirePermission only lets through admins whose role grants the permission.
+// The role is read from the database on every request so that role changes
+// take effect without waiting for access tokens to expire.
+// It must run after AuthMiddleware.
+func RequirePermission(store AdminRoleGetter, permission rbac.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := loadAdminRole(c, store)
+		if !ok {
+			return
+		}
+
+		if !role.Can(permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you don't have permission to perform this action"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSelfOrPermission lets admins act on their own account, identified by
+// the given path parameter, and requires the permission to act on anyone else's
+func RequireSelfOrPermission(store AdminRoleGetter, param string, permission rbac.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload, err := GetPayload(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		role, ok := loadAdminRole(c, store)
+		if !ok {
+			return
+		}
+
+		if c.Param(param) != payload.UserID && !role.Can(permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you can only modify your own account"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetAdminRole returns the role stored by RequirePermission or RequireSelfOrPermission
+func GetAdminRole(c *gin.Context) (rbac.Role, bool) {
+	value, exists := c.Get(AdminRoleKey)
+	if !exists {
+		return "", false
+	}
+	role, ok := value.(rbac.Role)
+	return role, ok
+}
+
+// loadAdminRole fetches the caller's role and stores it in the context,
+// aborting the request if it can't be determined
+func loadAdminRole(c *gin.Context, store AdminRoleGetter) (rbac.Role, bool) {
+	if role, ok := GetAdminRole(c); ok {
+		return role, true
+	}
+
+	payload, err := GetPayload(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return "", false
+	}
+
+	adminID, err := utils.StringToPgTypeUUID(payload.UserID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		return "", false
+	}
+
+	role, err := store.GetAdminRole(c, adminID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "admin account no longer exists"})
+			return "", false
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return "", false
+	}
+
+	c.Set(AdminRoleKey, rbac.Role(role))
+	return rbac.Role(role), true
+}
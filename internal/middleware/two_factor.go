@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"lingo/pkg/auth/rbac"
+	"lingo/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TwoFactorStatusGetter looks up an admin's role and whether they have enabled two-factor authentication
+type TwoFactorStatusGetter interface {
+	AdminRoleGetter
+	IsAdminTotpEnabled(ctx context.Context, adminID pgtype.UUID) (bool, error)
+}
+
+// RequireTwoFactor blocks admins whose role must use two-factor authentication
+// until they have enabled it. It must run after AuthMiddleware.
+func RequireTwoFactor(store TwoFactorStatusGetter, policy rbac.TwoFactorPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := loadAdminRole(c, store)
+		if !ok {
+			return
+		}
+		if !policy.Requires(role) {
+			c.Next()
+			return
+		}
+
+		payload, err := GetPayload(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		adminID, err := utils.StringToPgTypeUUID(payload.UserID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		enabled, err := store.IsAdminTotpEnabled(c, adminID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to check two-factor authentication"})
+			return
+		}
+		if !enabled {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "your role requires two-factor authentication, please enable it first"})
+			return
+		}
+		c.Next()
+	}
+}
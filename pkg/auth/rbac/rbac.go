@@ -0,0 +1,89 @@
+package rbac
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role is the level of access an admin account has
+type Role string
+
+const (
+	RoleSuperAdmin    Role = "super_admin"
+	RoleContentEditor Role = "content_editor"
+	RoleReviewer      Role = "reviewer"
+)
+
+// Permission is an action guarded on admin routes
+type Permission string
+
+const (
+	// PermReadContent allows viewing languages, courses, lessons, exercises and vocabulary
+	PermReadContent Permission = "content:read"
+	// PermWriteContent allows creating and editing languages, courses, lessons, exercises and vocabulary
+	PermWriteContent Permission = "content:write"
+	// PermDeleteLessons allows deleting lessons, exercises and vocabulary
+	PermDeleteLessons Permission = "lessons:delete"
+	// PermDeleteCourses allows deleting courses, along with their lessons and exercises
+	PermDeleteCourses Permission = "courses:delete"
+	// PermDeleteLanguages allows deleting languages, along with everything under them
+	PermDeleteLanguages Permission = "languages:delete"
+	// PermManageAdmins allows creating admins, assigning roles and editing other admins
+	PermManageAdmins Permission = "admins:manage"
+)
+
+var permissions = map[Role]map[Permission]bool{
+	RoleSuperAdmin: {
+		PermReadContent:     true,
+		PermWriteContent:    true,
+		PermDeleteLessons:   true,
+		PermDeleteCourses:   true,
+		PermDeleteLanguages: true,
+		PermManageAdmins:    true,
+	},
+	RoleContentEditor: {
+		PermReadContent:   true,
+		PermWriteContent:  true,
+		PermDeleteLessons: true,
+	},
+	RoleReviewer: {
+		PermReadContent: true,
+	},
+}
+
+// Valid reports whether role is a known admin role
+func (role Role) Valid() bool {
+	_, ok := permissions[role]
+	return ok
+}
+
+// Can reports whether role has been granted permission
+func (role Role) Can(permission Permission) bool {
+	return permissions[role][permission]
+}
+
+// TwoFactorPolicy is the set of roles that must enable two-factor authentication
+type TwoFactorPolicy map[Role]bool
+
+// NewTwoFactorPolicy parses a comma separated list of roles that require
+// two-factor authentication. An empty list requires it for super admins only.
+func NewTwoFactorPolicy(roles string) (TwoFactorPolicy, error) {
+	if strings.TrimSpace(roles) == "" {
+		return TwoFactorPolicy{RoleSuperAdmin: true}, nil
+	}
+
+	policy := TwoFactorPolicy{}
+	for _, name := range strings.Split(roles, ",") {
+		role := Role(strings.TrimSpace(name))
+		if !role.Valid() {
+			return nil, fmt.Errorf("Invalid role %q in two-factor policy", role)
+		}
+		policy[role] = true
+	}
+	return policy, nil
+}
+
+// Requires reports whether admins with role must enable two-factor authentication
+func (policy TwoFactorPolicy) Requires(role Role) bool {
+	return policy[role]
+}
@@ -9,6 +9,16 @@ import (
 // Holds the interface for Paseto
 
 type Maker interface {
-	CreateToken(userID pgtype.UUID, duration time.Duration) (string, error)
+	CreateToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error)
+	CreateRefreshToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error)
+	CreateEmailVerificationToken(userID pgtype.UUID, role Role, email string, duration time.Duration) (string, *Payload, error)
+	CreateTwoFactorToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error)
+	// VerifyToken only accepts access tokens
 	VerifyToken(token string) (*Payload, error)
+	// VerifyRefreshToken only accepts refresh tokens
+	VerifyRefreshToken(token string) (*Payload, error)
+	// VerifyEmailVerificationToken only accepts email verification tokens
+	VerifyEmailVerificationToken(token string) (*Payload, error)
+	// VerifyTwoFactorToken only accepts two-factor challenge tokens
+	VerifyTwoFactorToken(token string) (*Payload, error)
 }
@@ -12,42 +12,89 @@ import (
 type PasetoMaker struct {
 	paseto       *paseto.V2
 	symmetricKey []byte
+	clockSkew    time.Duration
 }
 
-func NewPasetoMaker(symmetricKey string) (Maker, error) {
+// NewPasetoMaker creates a symmetric PASETO maker. clockSkew is the leeway
+// allowed when checking issued-at and expiry times.
+func NewPasetoMaker(symmetricKey string, clockSkew time.Duration) (Maker, error) {
 	if len(symmetricKey) != chacha20poly1305.KeySize {
 		return nil, fmt.Errorf("Invalid key size: must be exactly %d chars", chacha20poly1305.KeySize)
 	}
+	if clockSkew < 0 {
+		return nil, fmt.Errorf("Invalid clock skew: must not be negative")
+	}
 	maker := &PasetoMaker{
 		paseto:       paseto.NewV2(),
 		symmetricKey: []byte(symmetricKey),
+		clockSkew:    clockSkew,
 	}
 	return maker, nil
 }
 
-func (maker *PasetoMaker) CreateToken(userID pgtype.UUID, duration time.Duration) (string, error) {
-	payload, err := NewPayload(userID.String(), duration)
+func (maker *PasetoMaker) CreateToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID.String(), role, TokenTypeAccess, duration)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	return maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
 }
 
-func (maker *PasetoMaker) CreateRefreshToken(userID pgtype.UUID, duration time.Duration) (string, error) {
-	payload, err := NewPayload(userID.String(), duration)
+func (maker *PasetoMaker) CreateRefreshToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID.String(), role, TokenTypeRefresh, duration)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	return maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
 }
 
+func (maker *PasetoMaker) CreateEmailVerificationToken(userID pgtype.UUID, role Role, email string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID.String(), role, TokenTypeEmailVerification, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	payload.Email = email
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
+}
+
+func (maker *PasetoMaker) CreateTwoFactorToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID.String(), role, TokenTypeTwoFactor, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
+}
 
 func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeAccess)
+}
+
+func (maker *PasetoMaker) VerifyRefreshToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeRefresh)
+}
+
+func (maker *PasetoMaker) VerifyEmailVerificationToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeEmailVerification)
+}
+
+func (maker *PasetoMaker) VerifyTwoFactorToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeTwoFactor)
+}
+
+// verify decrypts the token and makes sure it is unexpired and of the expected type
+func (maker *PasetoMaker) verify(token string, tokenType TokenType) (*Payload, error) {
 	payload := &Payload{}
 
 	err := maker.paseto.Decrypt(token, maker.symmetricKey, payload, nil)
 	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if err := validatePayload(payload, tokenType, maker.clockSkew); err != nil {
 		return nil, err
 	}
 	return payload, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,208 @@
+package tokengen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+const testSymmetricKey = "12345678901234567890123456789012"
+
+func randomUserID(t *testing.T) pgtype.UUID {
+	id, err := uuid.NewRandom()
+	require.NoError(t, err)
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+// encryptPayload issues an access token for userID issued issuedOffset from now
+func encryptPayload(t *testing.T, maker Maker, userID pgtype.UUID, issuedOffset, duration time.Duration) string {
+	payload, err := NewPayload(userID.String(), RoleAdmin, TokenTypeAccess, duration)
+	require.NoError(t, err)
+	payload.IssuedAt = payload.IssuedAt.Add(issuedOffset)
+	payload.ExpiredAt = payload.IssuedAt.Add(duration)
+
+	token, err := maker.(*PasetoMaker).paseto.Encrypt([]byte(testSymmetricKey), payload, nil)
+	require.NoError(t, err)
+	return token
+}
+
+func TestNewPasetoMaker(t *testing.T) {
+	testCases := []struct {
+		name      string
+		key       string
+		clockSkew time.Duration
+		wantErr   bool
+	}{
+		{name: "ValidKey", key: testSymmetricKey, clockSkew: time.Minute},
+		{name: "ShortKey", key: "too-short", wantErr: true},
+		{name: "LongKey", key: testSymmetricKey + "x", wantErr: true},
+		{name: "NegativeSkew", key: testSymmetricKey, clockSkew: -time.Second, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maker, err := NewPasetoMaker(tc.key, tc.clockSkew)
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, maker)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, maker)
+		})
+	}
+}
+
+func TestPasetoMakerVerifyToken(t *testing.T) {
+	maker, err := NewPasetoMaker(testSymmetricKey, 5*time.Second)
+	require.NoError(t, err)
+
+	otherMaker, err := NewPasetoMaker("abcdefghijabcdefghijabcdefghijab", 0)
+	require.NoError(t, err)
+
+	userID := randomUserID(t)
+
+	testCases := []struct {
+		name    string
+		token   func(t *testing.T) string
+		verify  func(token string) (*Payload, error)
+		wantErr error
+	}{
+		{
+			name: "ValidAccessToken",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateToken(userID, RoleAdmin, time.Minute)
+				require.NoError(t, err)
+				return token
+			},
+			verify: maker.VerifyToken,
+		},
+		{
+			name: "ValidRefreshToken",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateRefreshToken(userID, RoleLearner, time.Hour)
+				require.NoError(t, err)
+				return token
+			},
+			verify: maker.VerifyRefreshToken,
+		},
+		{
+			name: "ExpiredToken",
+			token: func(t *testing.T) string {
+				return encryptPayload(t, maker, userID, -time.Hour, time.Minute)
+			},
+			verify:  maker.VerifyToken,
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name: "ExpiredWithinClockSkew",
+			token: func(t *testing.T) string {
+				return encryptPayload(t, maker, userID, -time.Minute-time.Second, time.Minute)
+			},
+			verify: maker.VerifyToken,
+		},
+		{
+			name: "IssuedInTheFuture",
+			token: func(t *testing.T) string {
+				return encryptPayload(t, maker, userID, time.Minute, time.Hour)
+			},
+			verify:  maker.VerifyToken,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "RefreshTokenUsedAsAccessToken",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateRefreshToken(userID, RoleAdmin, time.Hour)
+				require.NoError(t, err)
+				return token
+			},
+			verify:  maker.VerifyToken,
+			wantErr: ErrInvalidTokenType,
+		},
+		{
+			name: "AccessTokenUsedAsRefreshToken",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateToken(userID, RoleLearner, time.Minute)
+				require.NoError(t, err)
+				return token
+			},
+			verify:  maker.VerifyRefreshToken,
+			wantErr: ErrInvalidTokenType,
+		},
+		{
+			name: "ValidEmailVerificationToken",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateEmailVerificationToken(userID, RoleLearner, "learner@example.com", time.Hour)
+				require.NoError(t, err)
+				return token
+			},
+			verify: maker.VerifyEmailVerificationToken,
+		},
+		{
+			name: "AccessTokenUsedAsEmailVerificationToken",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateToken(userID, RoleLearner, time.Minute)
+				require.NoError(t, err)
+				return token
+			},
+			verify:  maker.VerifyEmailVerificationToken,
+			wantErr: ErrInvalidTokenType,
+		},
+		{
+			name: "TwoFactorTokenUsedAsAccessToken",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateTwoFactorToken(userID, RoleAdmin, time.Minute)
+				require.NoError(t, err)
+				return token
+			},
+			verify:  maker.VerifyToken,
+			wantErr: ErrInvalidTokenType,
+		},
+		{
+			name: "SignedWithAnotherKey",
+			token: func(t *testing.T) string {
+				token, _, err := otherMaker.CreateToken(userID, RoleAdmin, time.Minute)
+				require.NoError(t, err)
+				return token
+			},
+			verify:  maker.VerifyToken,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "Tampered",
+			token: func(t *testing.T) string {
+				token, _, err := maker.CreateToken(userID, RoleAdmin, time.Minute)
+				require.NoError(t, err)
+				return token[:len(token)-2] + "xx"
+			},
+			verify:  maker.VerifyToken,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "Garbage",
+			token: func(t *testing.T) string {
+				return "not-a-token"
+			},
+			verify:  maker.VerifyToken,
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := tc.verify(tc.token(t))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Nil(t, payload)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, payload)
+			require.Equal(t, userID.String(), payload.UserID)
+			require.NotEqual(t, uuid.Nil, payload.ID)
+		})
+	}
+}
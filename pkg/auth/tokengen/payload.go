@@ -1,30 +1,94 @@
 package tokengen
 
 import (
+	"errors"
 	"time"
+
 	"github.com/google/uuid"
 )
 
+// Role identifies the kind of account (and therefore the audience) a token was issued for
+type Role string
+
+const (
+	RoleAdmin   Role = "admin"
+	RoleLearner Role = "learner"
+)
+
+// TokenType distinguishes short-lived access tokens from refresh, email
+// verification and two-factor challenge tokens
+type TokenType string
+
+const (
+	TokenTypeAccess            TokenType = "access"
+	TokenTypeRefresh           TokenType = "refresh"
+	TokenTypeEmailVerification TokenType = "email_verification"
+	// TokenTypeTwoFactor is issued after a correct password and only lets
+	// the holder complete login with a two-factor code
+	TokenTypeTwoFactor TokenType = "two_factor"
+)
+
+var (
+	ErrInvalidToken     = errors.New("token is invalid")
+	ErrExpiredToken     = errors.New("token has expired")
+	ErrInvalidTokenType = errors.New("token type is not valid for this use")
+)
+
 type Payload struct {
-	ID       uuid.UUID `json:"id"`
-	UserID  string    `json:"userid"`
+	ID        uuid.UUID `json:"id"`
+	UserID    string    `json:"userid"`
+	Role      Role      `json:"role"`
+	TokenType TokenType `json:"token_type"`
+	// Email is only set on email verification tokens, binding them to the address they were sent to
+	Email     string    `json:"email,omitempty"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-
-func NewPayload(userID string, duration time.Duration)(*Payload, error){
+func NewPayload(userID string, role Role, tokenType TokenType, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
 	payload := &Payload{
-		ID: tokenID,
-		UserID: userID,
-		IssuedAt: time.Now(),
+		ID:        tokenID,
+		UserID:    userID,
+		Role:      role,
+		TokenType: tokenType,
+		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
 
 	return payload, nil
-}
\ No newline at end of file
+}
+
+// Valid checks the payload's time claims against now, allowing for the given
+// clock skew between the issuing and verifying machines
+func (payload *Payload) Valid(now time.Time, clockSkew time.Duration) error {
+	if payload.IssuedAt.IsZero() || payload.ExpiredAt.IsZero() {
+		return ErrInvalidToken
+	}
+	if payload.ExpiredAt.Before(payload.IssuedAt) {
+		return ErrInvalidToken
+	}
+	// A token can't be used before it was issued
+	if now.Add(clockSkew).Before(payload.IssuedAt) {
+		return ErrInvalidToken
+	}
+	if now.Add(-clockSkew).After(payload.ExpiredAt) {
+		return ErrExpiredToken
+	}
+	return nil
+}
+
+// validatePayload checks the time claims and token type of a decoded payload
+func validatePayload(payload *Payload, tokenType TokenType, clockSkew time.Duration) error {
+	if err := payload.Valid(time.Now(), clockSkew); err != nil {
+		return err
+	}
+	if payload.TokenType != tokenType {
+		return ErrInvalidTokenType
+	}
+	return nil
+}
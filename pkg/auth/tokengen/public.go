@@ -0,0 +1,138 @@
+package tokengen
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/o1egl/paseto"
+)
+
+// Footer is attached unencrypted to every signed token so verifiers
+// can pick the public key it was signed with
+type Footer struct {
+	KeyID string `json:"kid"`
+}
+
+var ErrNoSigningKey = errors.New("maker has no private key to sign tokens with")
+
+// PublicKeyMaker signs tokens with an Ed25519 private key (PASETO v2.public).
+// Services that only verify tokens need just the public keys.
+type PublicKeyMaker struct {
+	paseto     *paseto.V2
+	keyID      string
+	privateKey ed25519.PrivateKey
+	publicKeys map[string]ed25519.PublicKey
+	clockSkew  time.Duration
+}
+
+// NewPublicKeyMaker creates an asymmetric PASETO maker. privateKey signs new
+// tokens under keyID and may be nil for a verify-only maker. publicKeys holds
+// the keys of previous key IDs that should still be accepted during a rotation.
+func NewPublicKeyMaker(keyID string, privateKey ed25519.PrivateKey, publicKeys map[string]ed25519.PublicKey, clockSkew time.Duration) (Maker, error) {
+	if clockSkew < 0 {
+		return nil, fmt.Errorf("Invalid clock skew: must not be negative")
+	}
+
+	keys := make(map[string]ed25519.PublicKey, len(publicKeys)+1)
+	for id, key := range publicKeys {
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("Invalid public key size for key ID %q", id)
+		}
+		keys[id] = key
+	}
+
+	if privateKey != nil {
+		if keyID == "" {
+			return nil, fmt.Errorf("Invalid key ID: must not be empty")
+		}
+		if len(privateKey) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("Invalid private key size: must be exactly %d bytes", ed25519.PrivateKeySize)
+		}
+		keys[keyID] = privateKey.Public().(ed25519.PublicKey)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("Public key maker needs at least one key")
+	}
+
+	maker := &PublicKeyMaker{
+		paseto:     paseto.NewV2(),
+		keyID:      keyID,
+		privateKey: privateKey,
+		publicKeys: keys,
+		clockSkew:  clockSkew,
+	}
+	return maker, nil
+}
+
+func (maker *PublicKeyMaker) CreateToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error) {
+	return maker.sign(userID, role, TokenTypeAccess, "", duration)
+}
+
+func (maker *PublicKeyMaker) CreateRefreshToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error) {
+	return maker.sign(userID, role, TokenTypeRefresh, "", duration)
+}
+
+func (maker *PublicKeyMaker) CreateEmailVerificationToken(userID pgtype.UUID, role Role, email string, duration time.Duration) (string, *Payload, error) {
+	return maker.sign(userID, role, TokenTypeEmailVerification, email, duration)
+}
+
+func (maker *PublicKeyMaker) CreateTwoFactorToken(userID pgtype.UUID, role Role, duration time.Duration) (string, *Payload, error) {
+	return maker.sign(userID, role, TokenTypeTwoFactor, "", duration)
+}
+
+func (maker *PublicKeyMaker) VerifyToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeAccess)
+}
+
+func (maker *PublicKeyMaker) VerifyRefreshToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeRefresh)
+}
+
+func (maker *PublicKeyMaker) VerifyEmailVerificationToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeEmailVerification)
+}
+
+func (maker *PublicKeyMaker) VerifyTwoFactorToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenTypeTwoFactor)
+}
+
+func (maker *PublicKeyMaker) sign(userID pgtype.UUID, role Role, tokenType TokenType, email string, duration time.Duration) (string, *Payload, error) {
+	if maker.privateKey == nil {
+		return "", nil, ErrNoSigningKey
+	}
+
+	payload, err := NewPayload(userID.String(), role, tokenType, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	payload.Email = email
+	token, err := maker.paseto.Sign(maker.privateKey, payload, Footer{KeyID: maker.keyID})
+	return token, payload, err
+}
+
+// verify checks the signature against the public key named in the footer and
+// makes sure the token is unexpired and of the expected type
+func (maker *PublicKeyMaker) verify(token string, tokenType TokenType) (*Payload, error) {
+	var footer Footer
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, ok := maker.publicKeys[footer.KeyID]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	if err := maker.paseto.Verify(token, publicKey, payload, nil); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if err := validatePayload(payload, tokenType, maker.clockSkew); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
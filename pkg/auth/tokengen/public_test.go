@@ -0,0 +1,125 @@
+package tokengen
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newKeyPair(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return publicKey, privateKey
+}
+
+func TestPublicKeyMakerVerifyToken(t *testing.T) {
+	oldPublicKey, oldPrivateKey := newKeyPair(t)
+	_, newPrivateKey := newKeyPair(t)
+	_, strangerPrivateKey := newKeyPair(t)
+
+	oldMaker, err := NewPublicKeyMaker("old", oldPrivateKey, nil, 0)
+	require.NoError(t, err)
+
+	// After rotating, new tokens are signed with "new" but "old" tokens stay valid
+	maker, err := NewPublicKeyMaker("new", newPrivateKey, map[string]ed25519.PublicKey{"old": oldPublicKey}, 0)
+	require.NoError(t, err)
+
+	strangerMaker, err := NewPublicKeyMaker("new", strangerPrivateKey, nil, 0)
+	require.NoError(t, err)
+
+	symmetricMaker, err := NewPasetoMaker(testSymmetricKey, 0)
+	require.NoError(t, err)
+
+	userID := randomUserID(t)
+
+	testCases := []struct {
+		name    string
+		issuer  Maker
+		verify  func(token string) (*Payload, error)
+		wantErr error
+	}{
+		{name: "CurrentKey", issuer: maker, verify: maker.VerifyToken},
+		{name: "RotatedOutKey", issuer: oldMaker, verify: maker.VerifyToken},
+		{name: "UnknownKeyID", issuer: maker, verify: oldMaker.VerifyToken, wantErr: ErrInvalidToken},
+		{name: "SameKeyIDDifferentKey", issuer: strangerMaker, verify: maker.VerifyToken, wantErr: ErrInvalidToken},
+		{name: "SymmetricToken", issuer: symmetricMaker, verify: maker.VerifyToken, wantErr: ErrInvalidToken},
+		{name: "WrongTokenType", issuer: maker, verify: maker.VerifyRefreshToken, wantErr: ErrInvalidTokenType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, _, err := tc.issuer.CreateToken(userID, RoleLearner, time.Minute)
+			require.NoError(t, err)
+
+			payload, err := tc.verify(token)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Nil(t, payload)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, userID.String(), payload.UserID)
+			require.Equal(t, RoleLearner, payload.Role)
+		})
+	}
+}
+
+func TestPublicKeyMakerVerifyOnly(t *testing.T) {
+	publicKey, privateKey := newKeyPair(t)
+
+	signer, err := NewPublicKeyMaker("k1", privateKey, nil, 0)
+	require.NoError(t, err)
+
+	verifier, err := NewPublicKeyMaker("", nil, map[string]ed25519.PublicKey{"k1": publicKey}, 0)
+	require.NoError(t, err)
+
+	token, _, err := signer.CreateRefreshToken(randomUserID(t), RoleAdmin, time.Hour)
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyRefreshToken(token)
+	require.NoError(t, err)
+
+	_, _, err = verifier.CreateToken(randomUserID(t), RoleAdmin, time.Hour)
+	require.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestLoadKeys(t *testing.T) {
+	publicKey, privateKey := newKeyPair(t)
+	dir := t.TempDir()
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privatePath := filepath.Join(dir, "paseto.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600))
+
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicPath := filepath.Join(dir, "paseto.pub.pem")
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+
+	loadedPrivate, err := LoadPrivateKey(privatePath)
+	require.NoError(t, err)
+	require.True(t, privateKey.Equal(loadedPrivate))
+
+	loadedPublic, err := LoadPublicKey(publicPath)
+	require.NoError(t, err)
+	require.True(t, publicKey.Equal(loadedPublic))
+
+	keys, err := LoadPublicKeys(" k1=" + publicPath + ", ")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.True(t, publicKey.Equal(keys["k1"]))
+
+	_, err = LoadPublicKeys("k1")
+	require.Error(t, err)
+
+	_, err = LoadPrivateKey(publicPath)
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 seed used by the RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeAt(t *testing.T) {
+	// RFC 6238 appendix B, truncated to 6 digits
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, tc := range testCases {
+		code, err := CodeAt(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	testCases := []struct {
+		name   string
+		code   string
+		wantOK bool
+	}{
+		{name: "CurrentStep", code: "050471", wantOK: true},
+		{name: "PreviousStepWithinSkew", code: mustCode(t, Step(now)-1), wantOK: true},
+		{name: "NextStepWithinSkew", code: mustCode(t, Step(now)+1), wantOK: true},
+		{name: "OutsideSkew", code: mustCode(t, Step(now)-2)},
+		{name: "WrongCode", code: "123456"},
+		{name: "WrongLength", code: "05047"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok, err := Validate(rfcSecret, tc.code, now, 1)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantOK, ok)
+		})
+	}
+
+	_, _, err := Validate("not base32!", "123456", now, 1)
+	require.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	_, err = CodeAt(secret, 1)
+	require.NoError(t, err)
+
+	uri := URI("Lingo", "admin@example.com", secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Lingo:admin@example.com?"))
+	require.Contains(t, uri, "secret="+secret)
+}
+
+func mustCode(t *testing.T, step int64) string {
+	code, err := CodeAt(rfcSecret, step)
+	require.NoError(t, err)
+	return code
+}
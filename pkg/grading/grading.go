@@ -0,0 +1,138 @@
+// Package grading scores learner answers to exercises.
+// Scores run from 0 to 100 to match the user_progress.valid_score constraint.
+package grading
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Exercise types, as stored in exercises.exercise_type
+const (
+	TypeMultipleChoice = "MultipleChoice"
+	TypeFillBlank      = "FillBlank"
+	TypeListening      = "Listening"
+	TypeSpeaking       = "Speaking"
+)
+
+const (
+	MaxScore = 100
+	// PassingScore is the lowest score that counts an exercise as completed
+	PassingScore = 80
+)
+
+var (
+	ErrUnknownType   = errors.New("unknown exercise type")
+	ErrInvalidAnswer = errors.New("answer is not one of the options")
+)
+
+// Result is the outcome of grading a single answer
+type Result struct {
+	Score    int
+	Correct  bool
+	Feedback string
+}
+
+// Grade scores an answer against an exercise's correct answer.
+// options is the exercise's raw options column, holding the choices of a
+// multiple choice exercise or the settings of a fill in the blank one.
+func Grade(exerciseType, correctAnswer string, options []byte, answer string) (Result, error) {
+	switch exerciseType {
+	case TypeMultipleChoice:
+		return gradeMultipleChoice(correctAnswer, options, answer)
+	case TypeFillBlank:
+		m, accepted, err := fillBlankMatcher(correctAnswer, options)
+		if err != nil {
+			return Result{}, err
+		}
+		return m.Match(answer, accepted), nil
+	case TypeListening, TypeSpeaking:
+		// Speaking answers are the transcript from the client's speech recognition
+		return gradeWords(correctAnswer, answer), nil
+	default:
+		return Result{}, fmt.Errorf("%w: %q", ErrUnknownType, exerciseType)
+	}
+}
+
+// choices reads the options of a multiple choice exercise, stored as {"options": [...]}
+func choices(options []byte) ([]string, error) {
+	var parsed struct {
+		Options []string `json:"options"`
+	}
+	if err := json.Unmarshal(options, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid multiple choice options: %w", err)
+	}
+	return parsed.Options, nil
+}
+
+func gradeMultipleChoice(correctAnswer string, options []byte, answer string) (Result, error) {
+	opts, err := choices(options)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Options with tone marks can arrive in either Unicode composition form
+	answer = norm.NFC.String(strings.TrimSpace(answer))
+	found := false
+	for _, opt := range opts {
+		if norm.NFC.String(strings.TrimSpace(opt)) == answer {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return Result{}, ErrInvalidAnswer
+	}
+
+	if answer == norm.NFC.String(strings.TrimSpace(correctAnswer)) {
+		return Result{Score: MaxScore, Correct: true, Feedback: "Correct!"}, nil
+	}
+	return Result{Score: 0, Feedback: "Not quite, that isn't the right option."}, nil
+}
+
+// gradeWords gives partial credit by how many words of the answer have to be
+// added, removed or replaced to match the correct answer
+func gradeWords(correctAnswer, answer string) Result {
+	want := strings.Fields(fold(correctAnswer))
+	got := strings.Fields(fold(answer))
+
+	longest := max(len(want), len(got))
+	if longest == 0 {
+		return Result{Score: MaxScore, Correct: true, Feedback: "Correct!"}
+	}
+
+	score := MaxScore * (longest - editDistance(want, got)) / longest
+	switch {
+	case score == MaxScore:
+		return Result{Score: score, Correct: true, Feedback: "Correct!"}
+	case score >= PassingScore:
+		return Result{Score: score, Correct: true, Feedback: "Almost perfect, a few words were off."}
+	default:
+		return Result{Score: score, Feedback: "Not quite, listen again and try once more."}
+	}
+}
+
+// editDistance is the Levenshtein distance between two sequences
+func editDistance[T comparable](a, b []T) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
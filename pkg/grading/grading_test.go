@@ -0,0 +1,106 @@
+package grading
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestGrade(t *testing.T) {
+	options := []byte(`{"options": ["Ẹ káàárọ̀", "Ẹ káàsán", "Ẹ kú alẹ́"]}`)
+
+	testCases := []struct {
+		name          string
+		exerciseType  string
+		correctAnswer string
+		options       []byte
+		answer        string
+		wantScore     int
+		wantCorrect   bool
+		wantErr       error
+	}{
+		{
+			name:          "MultipleChoiceCorrect",
+			exerciseType:  TypeMultipleChoice,
+			correctAnswer: "Ẹ káàárọ̀",
+			options:       options,
+			answer:        norm.NFD.String(" Ẹ káàárọ̀ "),
+			wantScore:     100,
+			wantCorrect:   true,
+		},
+		{
+			name:          "MultipleChoiceWrong",
+			exerciseType:  TypeMultipleChoice,
+			correctAnswer: "Ẹ káàárọ̀",
+			options:       options,
+			answer:        "Ẹ káàsán",
+		},
+		{
+			name:          "MultipleChoiceNotAnOption",
+			exerciseType:  TypeMultipleChoice,
+			correctAnswer: "Ẹ káàárọ̀",
+			options:       options,
+			answer:        "Hello",
+			wantErr:       ErrInvalidAnswer,
+		},
+		{
+			name:          "FillBlankIgnoresCaseAndPunctuation",
+			exerciseType:  TypeFillBlank,
+			correctAnswer: "Sannu",
+			answer:        "sannu!",
+			wantScore:     100,
+			wantCorrect:   true,
+		},
+		{
+			name:          "FillBlankWrong",
+			exerciseType:  TypeFillBlank,
+			correctAnswer: "Sannu",
+			answer:        "Nagode",
+		},
+		{
+			name:          "ListeningExact",
+			exerciseType:  TypeListening,
+			correctAnswer: "Kedu ka i mere",
+			answer:        "kedu ka i mere",
+			wantScore:     100,
+			wantCorrect:   true,
+		},
+		{
+			name:          "SpeakingOneWordOff",
+			exerciseType:  TypeSpeaking,
+			correctAnswer: "Kedu ka i mere taa",
+			answer:        "kedu ka o mere taa",
+			wantScore:     80,
+			wantCorrect:   true,
+		},
+		{
+			name:          "SpeakingMostlyWrong",
+			exerciseType:  TypeSpeaking,
+			correctAnswer: "Kedu ka i mere",
+			answer:        "kedu",
+			wantScore:     25,
+		},
+		{
+			name:          "UnknownType",
+			exerciseType:  "Essay",
+			correctAnswer: "anything",
+			answer:        "anything",
+			wantErr:       ErrUnknownType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Grade(tc.exerciseType, tc.correctAnswer, tc.options, tc.answer)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantScore, result.Score)
+			require.Equal(t, tc.wantCorrect, result.Correct)
+			require.NotEmpty(t, result.Feedback)
+		})
+	}
+}
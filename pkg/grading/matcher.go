@@ -0,0 +1,148 @@
+package grading
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Matcher grades free text answers in languages written with tone marks and
+// subdots, such as Yoruba, Igbo and Hausa. Learners on phone keyboards often
+// leave the marks out or type them in a different Unicode composition form.
+type Matcher struct {
+	// ToneLessScore is awarded when the answer was typed without any tone marks,
+	// subdots or hooked letters and otherwise matches. Answers typed with the
+	// wrong marks get nothing. Zero requires the marks to be exact.
+	ToneLessScore int
+	// MaxTypos is how many letters can be added, removed or replaced
+	MaxTypos int
+	// TypoPenalty is taken off the score for each typo
+	TypoPenalty int
+	// MinTypoLength keeps short answers from matching a different word by a typo
+	MinTypoLength int
+}
+
+// DefaultMatcher gives partial credit, below PassingScore, for answers without
+// tone marks, and accepts single typos in longer answers
+var DefaultMatcher = Matcher{
+	ToneLessScore: 60,
+	MaxTypos:      1,
+	TypoPenalty:   10,
+	MinTypoLength: 5,
+}
+
+// FillBlankOptions are the optional per exercise settings stored in the
+// options column of a FillBlank exercise
+type FillBlankOptions struct {
+	AcceptedAnswers []string `json:"accepted_answers"`
+	AllowToneLess   *bool    `json:"allow_toneless"`
+	MaxTypos        *int     `json:"max_typos"`
+}
+
+// hookedLetters are the Hausa letters that phone keyboards usually lack
+var hookedLetters = strings.NewReplacer("ɓ", "b", "ɗ", "d", "ƙ", "k", "ƴ", "y", "ʼ", "", "'", "")
+
+// fold puts an answer in NFC, lowercases it, drops punctuation and collapses whitespace
+func fold(s string) string {
+	s = norm.NFC.String(s)
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// stripMarks removes tone marks and subdots and replaces hooked letters with plain ones
+func stripMarks(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(fold(s)))
+	return norm.NFC.String(hookedLetters.Replace(s))
+}
+
+// Match grades an answer against every accepted answer and keeps the best result
+func (m Matcher) Match(answer string, accepted []string) Result {
+	best := Result{Score: 0, Feedback: "Not quite, check your spelling."}
+	for _, candidate := range accepted {
+		if result := m.match(answer, candidate); result.Score > best.Score {
+			best = result
+		}
+	}
+	return best
+}
+
+func (m Matcher) match(answer, candidate string) Result {
+	got, want := fold(answer), fold(candidate)
+	if want == "" {
+		return Result{}
+	}
+	if got == want {
+		return Result{Score: MaxScore, Correct: true, Feedback: "Correct!"}
+	}
+
+	// Differences in marks alone are never counted as typos. Only answers typed
+	// without marks get credit for them, not answers with the wrong marks.
+	gotPlain, wantPlain := stripMarks(answer), stripMarks(candidate)
+	unmarked := gotPlain == got
+	if gotPlain == wantPlain {
+		if !unmarked {
+			return Result{Score: 0, Feedback: fmt.Sprintf("Not quite, check the tone marks: %s", candidate)}
+		}
+		return Result{
+			Score:    m.ToneLessScore,
+			Correct:  m.ToneLessScore >= PassingScore,
+			Feedback: fmt.Sprintf("Almost, watch the tone marks: %s", candidate),
+		}
+	}
+
+	best := Result{Score: m.typoScore(got, want, MaxScore), Feedback: fmt.Sprintf("Almost, check your spelling: %s", candidate)}
+	if m.ToneLessScore > 0 && unmarked {
+		if score := m.typoScore(gotPlain, wantPlain, m.ToneLessScore); score > best.Score {
+			best = Result{Score: score, Feedback: fmt.Sprintf("Almost, check your spelling and tone marks: %s", candidate)}
+		}
+	}
+	best.Correct = best.Score >= PassingScore
+	return best
+}
+
+// typoScore takes the typo penalty off base for each edit, or returns 0 for too many edits
+func (m Matcher) typoScore(got, want string, base int) int {
+	wantRunes := []rune(want)
+	if m.MaxTypos <= 0 || len(wantRunes) < m.MinTypoLength {
+		return 0
+	}
+	typos := editDistance([]rune(got), wantRunes)
+	if typos > m.MaxTypos {
+		return 0
+	}
+	return max(base-typos*m.TypoPenalty, 0)
+}
+
+// fillBlankMatcher applies an exercise's settings to the default matcher
+func fillBlankMatcher(correctAnswer string, options []byte) (Matcher, []string, error) {
+	m := DefaultMatcher
+	accepted := []string{correctAnswer}
+	if len(options) == 0 {
+		return m, accepted, nil
+	}
+
+	var opts FillBlankOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return Matcher{}, nil, fmt.Errorf("invalid fill in the blank options: %w", err)
+	}
+	if opts.AllowToneLess != nil && !*opts.AllowToneLess {
+		m.ToneLessScore = 0
+	}
+	if opts.MaxTypos != nil {
+		m.MaxTypos = *opts.MaxTypos
+	}
+	return m, append(accepted, opts.AcceptedAnswers...), nil
+}
@@ -0,0 +1,145 @@
+package grading
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestMatcherMatch(t *testing.T) {
+	testCases := []struct {
+		name        string
+		matcher     Matcher
+		answer      string
+		accepted    []string
+		wantScore   int
+		wantCorrect bool
+	}{
+		{
+			name:        "Exact",
+			matcher:     DefaultMatcher,
+			answer:      "Ẹ káàárọ̀",
+			accepted:    []string{"Ẹ káàárọ̀"},
+			wantScore:   100,
+			wantCorrect: true,
+		},
+		{
+			name:        "DecomposedForm",
+			matcher:     DefaultMatcher,
+			answer:      norm.NFD.String("ẹ káàárọ̀"),
+			accepted:    []string{norm.NFC.String("Ẹ káàárọ̀")},
+			wantScore:   100,
+			wantCorrect: true,
+		},
+		{
+			name:      "ToneLess",
+			matcher:   DefaultMatcher,
+			answer:    "e kaaaro",
+			accepted:  []string{"Ẹ káàárọ̀"},
+			wantScore: 60,
+		},
+		{
+			name:      "WrongToneMarks",
+			matcher:   DefaultMatcher,
+			answer:    "ọkọ̀",
+			accepted:  []string{"ọ̀kọ́"},
+			wantScore: 0,
+		},
+		{
+			name:      "WrongToneMarksInLongAnswer",
+			matcher:   DefaultMatcher,
+			answer:    "Ẹ káàárọ́",
+			accepted:  []string{"Ẹ káàárọ̀"},
+			wantScore: 0,
+		},
+		{
+			name:      "MissingMarkIsNotATypo",
+			matcher:   Matcher{MaxTypos: 1, TypoPenalty: 10, MinTypoLength: 5},
+			answer:    "e kaaaro",
+			accepted:  []string{"Ẹ káàárọ̀"},
+			wantScore: 0,
+		},
+		{
+			name:      "HookedLetters",
+			matcher:   DefaultMatcher,
+			answer:    "yaya kake",
+			accepted:  []string{"Yaya ƙake"},
+			wantScore: 60,
+		},
+		{
+			name:        "OneTypo",
+			matcher:     DefaultMatcher,
+			answer:      "Daali nụ",
+			accepted:    []string{"Daalụ nụ"},
+			wantScore:   90,
+			wantCorrect: true,
+		},
+		{
+			name:      "ToneLessWithTypo",
+			matcher:   DefaultMatcher,
+			answer:    "e kaaro",
+			accepted:  []string{"Ẹ káàárọ̀"},
+			wantScore: 50,
+		},
+		{
+			name:      "TooManyTypos",
+			matcher:   DefaultMatcher,
+			answer:    "Daalo no",
+			accepted:  []string{"Daalụ nụ"},
+			wantScore: 0,
+		},
+		{
+			name:      "ShortAnswerTypo",
+			matcher:   DefaultMatcher,
+			answer:    "ọmọ",
+			accepted:  []string{"ọkọ"},
+			wantScore: 0,
+		},
+		{
+			name:        "AlternativeAnswer",
+			matcher:     DefaultMatcher,
+			answer:      "Ẹ n lẹ",
+			accepted:    []string{"Ẹ káàbọ̀", "Ẹ n lẹ"},
+			wantScore:   100,
+			wantCorrect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.matcher.Match(tc.answer, tc.accepted)
+			require.Equal(t, tc.wantScore, result.Score)
+			require.Equal(t, tc.wantCorrect, result.Correct)
+			require.NotEmpty(t, result.Feedback)
+		})
+	}
+}
+
+func TestGradeFillBlankOptions(t *testing.T) {
+	testCases := []struct {
+		name      string
+		options   []byte
+		answer    string
+		wantScore int
+		wantErr   bool
+	}{
+		{name: "NoOptions", answer: "e kaaaro", wantScore: 60},
+		{name: "AcceptedAnswers", options: []byte(`{"accepted_answers": ["Ẹ kú àárọ̀"]}`), answer: "ẹ kú àárọ̀", wantScore: 100},
+		{name: "ToneLessDisabled", options: []byte(`{"allow_toneless": false}`), answer: "e kaaaro", wantScore: 0},
+		{name: "TyposDisabled", options: []byte(`{"max_typos": 0}`), answer: "Ẹ káàrọ̀", wantScore: 0},
+		{name: "InvalidOptions", options: []byte(`["Ẹ káàárọ̀"]`), answer: "Ẹ káàárọ̀", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Grade(TypeFillBlank, "Ẹ káàárọ̀", tc.options, tc.answer)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantScore, result.Score)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Package league places learners in weekly leagues of similar tiers. At the end
+// of each week the top of every league moves up a tier and the bottom moves down.
+package league
+
+import "time"
+
+const (
+	// Size is how many learners share a league
+	Size = 30
+	// PromoteCount is how many learners at the top of a full league move up a tier
+	PromoteCount = 7
+	// DemoteCount is how many learners at the bottom of a full league move down a tier
+	DemoteCount = 5
+)
+
+// Outcomes of a week in a league, as stored on league members
+const (
+	OutcomePromoted = "promoted"
+	OutcomeDemoted  = "demoted"
+	OutcomeStayed   = "stayed"
+)
+
+var tierNames = []string{
+	"Bronze",
+	"Silver",
+	"Gold",
+	"Sapphire",
+	"Ruby",
+	"Emerald",
+	"Amethyst",
+	"Pearl",
+	"Obsidian",
+	"Diamond",
+}
+
+// MaxTier is the highest tier, which nobody can be promoted out of
+var MaxTier = int32(len(tierNames) - 1)
+
+// TierName returns the display name of tier
+func TierName(tier int32) string {
+	if tier < 0 || tier > MaxTier {
+		return ""
+	}
+	return tierNames[tier]
+}
+
+// WeekStart returns the Monday, in UTC, of the league week containing t.
+// Leagues mix learners from every timezone, so their weeks follow UTC.
+func WeekStart(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	offset := (int(today.Weekday()) + 6) % 7
+	return today.AddDate(0, 0, -offset)
+}
+
+// Promotions returns how many learners at the top of a league of members move
+// up a tier. Smaller leagues promote proportionally fewer, rounded down, so
+// learners can't climb tiers just by being alone in one.
+func Promotions(members int) int {
+	return members * PromoteCount / Size
+}
+
+// Demotions returns how many learners at the bottom of a league of members move
+// down a tier, scaled like Promotions
+func Demotions(members int) int {
+	return members * DemoteCount / Size
+}
+
+// Outcome returns what finishing at rank, counting from 1, among members
+// learners in a league of tier does to a learner
+func Outcome(tier int32, rank, members int) string {
+	if rank <= Promotions(members) {
+		if tier < MaxTier {
+			return OutcomePromoted
+		}
+		return OutcomeStayed
+	}
+	if rank > members-Demotions(members) && tier > 0 {
+		return OutcomeDemoted
+	}
+	return OutcomeStayed
+}
+
+// NextTier returns the tier a learner moves to after an outcome
+func NextTier(tier int32, outcome string) int32 {
+	switch outcome {
+	case OutcomePromoted:
+		return min(tier+1, MaxTier)
+	case OutcomeDemoted:
+		return max(tier-1, 0)
+	default:
+		return tier
+	}
+}
@@ -0,0 +1,69 @@
+package league
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, monday, WeekStart(monday))
+	require.Equal(t, monday, WeekStart(time.Date(2025, time.March, 16, 23, 59, 0, 0, time.UTC)))
+	require.Equal(t, monday.AddDate(0, 0, 7), WeekStart(time.Date(2025, time.March, 17, 0, 0, 0, 0, time.UTC)))
+
+	// Still Sunday in UTC, although it's already Monday in Lagos
+	lagos, err := time.LoadLocation("Africa/Lagos")
+	require.NoError(t, err)
+	require.Equal(t, monday, WeekStart(time.Date(2025, time.March, 17, 0, 30, 0, 0, lagos)))
+}
+
+func TestOutcome(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tier    int32
+		rank    int
+		members int
+		want    string
+	}{
+		{name: "Top", tier: 2, rank: 1, members: Size, want: OutcomePromoted},
+		{name: "LastPromoted", tier: 2, rank: PromoteCount, members: Size, want: OutcomePromoted},
+		{name: "Middle", tier: 2, rank: PromoteCount + 1, members: Size, want: OutcomeStayed},
+		{name: "FirstDemoted", tier: 2, rank: Size - DemoteCount + 1, members: Size, want: OutcomeDemoted},
+		{name: "Bottom", tier: 2, rank: Size, members: Size, want: OutcomeDemoted},
+		{name: "BottomOfLowestTier", tier: 0, rank: Size, members: Size, want: OutcomeStayed},
+		{name: "TopOfHighestTier", tier: MaxTier, rank: 1, members: Size, want: OutcomeStayed},
+		{name: "AloneInLeague", tier: 2, rank: 1, members: 1, want: OutcomeStayed},
+		{name: "SmallLeagueTop", tier: 2, rank: 1, members: 10, want: OutcomePromoted},
+		{name: "SmallLeagueMiddle", tier: 2, rank: 3, members: 10, want: OutcomeStayed},
+		{name: "SmallLeagueAboveBottom", tier: 2, rank: 9, members: 10, want: OutcomeStayed},
+		{name: "SmallLeagueBottom", tier: 2, rank: 10, members: 10, want: OutcomeDemoted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Outcome(tc.tier, tc.rank, tc.members))
+		})
+	}
+}
+
+func TestPromotionsAndDemotions(t *testing.T) {
+	require.Equal(t, PromoteCount, Promotions(Size))
+	require.Equal(t, DemoteCount, Demotions(Size))
+	require.Equal(t, 2, Promotions(10))
+	require.Equal(t, 1, Demotions(10))
+	require.Zero(t, Promotions(4))
+	require.Zero(t, Demotions(5))
+}
+
+func TestNextTier(t *testing.T) {
+	require.Equal(t, int32(3), NextTier(2, OutcomePromoted))
+	require.Equal(t, int32(1), NextTier(2, OutcomeDemoted))
+	require.Equal(t, int32(2), NextTier(2, OutcomeStayed))
+	require.Equal(t, MaxTier, NextTier(MaxTier, OutcomePromoted))
+	require.Equal(t, int32(0), NextTier(0, OutcomeDemoted))
+	require.Equal(t, "Bronze", TierName(0))
+	require.Equal(t, "Diamond", TierName(MaxTier))
+}
@@ -0,0 +1,77 @@
+// Package srs schedules exercise reviews with the SM-2 spaced repetition
+// algorithm. Each successful review spaces the next one further apart by the
+// item's ease factor, and a failed review starts the item over.
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// DefaultEase is the ease factor of a newly scheduled item
+	DefaultEase = 2.5
+	// MinEase stops hard items from being reviewed ever more often
+	MinEase = 1.3
+	// PassingQuality is the lowest quality that counts as remembering the answer
+	PassingQuality = 3
+)
+
+// Item is the review schedule of one exercise for one learner
+type Item struct {
+	Ease float64
+	// Interval is the number of days until the next review
+	Interval int32
+	// Repetitions counts the successful reviews in a row
+	Repetitions int32
+	Due         time.Time
+}
+
+// New returns the schedule of an item that hasn't been reviewed yet
+func New() Item {
+	return Item{Ease: DefaultEase}
+}
+
+// Quality converts a 0-100 grading score to an SM-2 response quality from 0 to 5.
+// A passing score of 80 is the lowest quality that is remembered.
+func Quality(score int) int {
+	switch {
+	case score >= 100:
+		return 5
+	case score >= 90:
+		return 4
+	case score >= 80:
+		return 3
+	case score >= 60:
+		return 2
+	case score >= 40:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Review updates the item after a review of the given quality at now
+func Review(it Item, quality int, now time.Time) Item {
+	quality = max(0, min(quality, 5))
+
+	if quality >= PassingQuality {
+		switch it.Repetitions {
+		case 0:
+			it.Interval = 1
+		case 1:
+			it.Interval = 6
+		default:
+			it.Interval = int32(math.Round(float64(it.Interval) * it.Ease))
+		}
+		it.Repetitions++
+	} else {
+		it.Repetitions = 0
+		it.Interval = 1
+	}
+
+	miss := float64(5 - quality)
+	it.Ease = max(MinEase, it.Ease+0.1-miss*(0.08+miss*0.02))
+	it.Due = now.AddDate(0, 0, int(it.Interval))
+	return it
+}
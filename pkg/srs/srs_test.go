@@ -0,0 +1,54 @@
+package srs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func TestQuality(t *testing.T) {
+	require.Equal(t, 5, Quality(100))
+	require.Equal(t, 4, Quality(95))
+	require.Equal(t, 3, Quality(80))
+	require.Equal(t, 2, Quality(79))
+	require.Equal(t, 1, Quality(40))
+	require.Equal(t, 0, Quality(0))
+}
+
+func TestReview(t *testing.T) {
+	it := Review(New(), 5, now)
+	require.Equal(t, int32(1), it.Interval)
+	require.Equal(t, int32(1), it.Repetitions)
+	require.InDelta(t, 2.6, it.Ease, 1e-9)
+	require.Equal(t, now.AddDate(0, 0, 1), it.Due)
+
+	it = Review(it, 4, now)
+	require.Equal(t, int32(6), it.Interval)
+	require.Equal(t, int32(2), it.Repetitions)
+	require.InDelta(t, 2.6, it.Ease, 1e-9)
+
+	// From the third review on, the interval grows by the ease factor
+	it = Review(it, 3, now)
+	require.Equal(t, int32(16), it.Interval)
+	require.Equal(t, int32(3), it.Repetitions)
+	require.InDelta(t, 2.46, it.Ease, 1e-9)
+	require.Equal(t, now.AddDate(0, 0, 16), it.Due)
+}
+
+func TestReviewFailed(t *testing.T) {
+	it := Item{Ease: 2.5, Interval: 16, Repetitions: 3}
+
+	it = Review(it, 1, now)
+	require.Equal(t, int32(1), it.Interval)
+	require.Equal(t, int32(0), it.Repetitions)
+	require.InDelta(t, 1.96, it.Ease, 1e-9)
+
+	// The ease factor never drops below MinEase
+	for i := 0; i < 5; i++ {
+		it = Review(it, 0, now)
+	}
+	require.Equal(t, MinEase, it.Ease)
+}
@@ -0,0 +1,95 @@
+// Package streak counts consecutive days of learning in the learner's own timezone.
+// Days are represented as midnight UTC of the learner's local calendar date.
+package streak
+
+import "time"
+
+const (
+	// FreezeEvery is how many streak days earn a streak freeze
+	FreezeEvery = 7
+	// MaxFreezes is how many streak freezes a learner can hold at once
+	MaxFreezes = 2
+)
+
+// State is a learner's streak as stored on their account
+type State struct {
+	Count   int32
+	Longest int32
+	Freezes int32
+	// LastDay is the last day the streak was extended, zero if never
+	LastDay time.Time
+}
+
+// Update describes what recording an activity did to a streak
+type Update struct {
+	// Extended is false if the streak was already extended today
+	Extended bool
+	// Reset is true if a missed day broke the previous streak
+	Reset bool
+	// FrozenDays are the missed days covered by streak freezes
+	FrozenDays []time.Time
+	// EarnedFreeze is true if the new count earned a streak freeze
+	EarnedFreeze bool
+}
+
+// Day returns the learner's local calendar day of t
+func Day(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts the days from a to b, both as returned by Day
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// Record extends the streak for an activity on today. A gap of missed days is
+// covered by streak freezes if the learner holds enough of them, and otherwise
+// starts a new streak.
+func Record(s State, today time.Time) (State, Update) {
+	var update Update
+	if !s.LastDay.IsZero() {
+		gap := daysBetween(s.LastDay, today)
+		// A timezone change can move today before the last extended day
+		if gap <= 0 {
+			return s, update
+		}
+
+		missed := int32(gap - 1)
+		switch {
+		case missed == 0:
+		case missed <= s.Freezes:
+			s.Freezes -= missed
+			for i := 1; i < gap; i++ {
+				update.FrozenDays = append(update.FrozenDays, s.LastDay.AddDate(0, 0, i))
+			}
+		default:
+			s.Count = 0
+			update.Reset = true
+		}
+	}
+
+	s.Count++
+	s.LastDay = today
+	s.Longest = max(s.Longest, s.Count)
+	update.Extended = true
+
+	if s.Count%FreezeEvery == 0 && s.Freezes < MaxFreezes {
+		s.Freezes++
+		update.EarnedFreeze = true
+	}
+	return s, update
+}
+
+// Current is the streak to show on today. A streak that can no longer be
+// saved by the learner's streak freezes shows as 0 until they are active again.
+func Current(s State, today time.Time) int32 {
+	if s.LastDay.IsZero() {
+		return 0
+	}
+	missed := int32(daysBetween(s.LastDay, today) - 1)
+	if missed > s.Freezes {
+		return 0
+	}
+	return s.Count
+}
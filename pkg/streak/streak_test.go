@@ -0,0 +1,111 @@
+package streak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(day int) time.Time {
+	return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDay(t *testing.T) {
+	lagos, err := time.LoadLocation("Africa/Lagos")
+	require.NoError(t, err)
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+
+	// 23:30 UTC is already the next day in Lagos but still the same day in Toronto
+	at := time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC)
+	require.Equal(t, date(11), Day(at, lagos))
+	require.Equal(t, date(10), Day(at, toronto))
+}
+
+func TestRecord(t *testing.T) {
+	testCases := []struct {
+		name       string
+		state      State
+		today      time.Time
+		want       State
+		wantUpdate Update
+	}{
+		{
+			name:       "FirstActivity",
+			today:      date(10),
+			want:       State{Count: 1, Longest: 1, LastDay: date(10)},
+			wantUpdate: Update{Extended: true},
+		},
+		{
+			name:  "SameDay",
+			state: State{Count: 3, Longest: 3, LastDay: date(10)},
+			today: date(10),
+			want:  State{Count: 3, Longest: 3, LastDay: date(10)},
+		},
+		{
+			name:  "TimezoneMovedBackwards",
+			state: State{Count: 3, Longest: 3, LastDay: date(10)},
+			today: date(9),
+			want:  State{Count: 3, Longest: 3, LastDay: date(10)},
+		},
+		{
+			name:       "NextDay",
+			state:      State{Count: 3, Longest: 5, LastDay: date(10)},
+			today:      date(11),
+			want:       State{Count: 4, Longest: 5, LastDay: date(11)},
+			wantUpdate: Update{Extended: true},
+		},
+		{
+			name:       "MissedDayResets",
+			state:      State{Count: 3, Longest: 3, LastDay: date(10)},
+			today:      date(12),
+			want:       State{Count: 1, Longest: 3, LastDay: date(12)},
+			wantUpdate: Update{Extended: true, Reset: true},
+		},
+		{
+			name:       "FreezeCoversMissedDay",
+			state:      State{Count: 3, Longest: 3, Freezes: 1, LastDay: date(10)},
+			today:      date(12),
+			want:       State{Count: 4, Longest: 4, LastDay: date(12)},
+			wantUpdate: Update{Extended: true, FrozenDays: []time.Time{date(11)}},
+		},
+		{
+			name:       "NotEnoughFreezes",
+			state:      State{Count: 3, Longest: 3, Freezes: 1, LastDay: date(10)},
+			today:      date(13),
+			want:       State{Count: 1, Longest: 3, Freezes: 1, LastDay: date(13)},
+			wantUpdate: Update{Extended: true, Reset: true},
+		},
+		{
+			name:       "EarnsFreeze",
+			state:      State{Count: 6, Longest: 6, LastDay: date(10)},
+			today:      date(11),
+			want:       State{Count: 7, Longest: 7, Freezes: 1, LastDay: date(11)},
+			wantUpdate: Update{Extended: true, EarnedFreeze: true},
+		},
+		{
+			name:       "FreezesAreCapped",
+			state:      State{Count: 13, Longest: 13, Freezes: MaxFreezes, LastDay: date(10)},
+			today:      date(11),
+			want:       State{Count: 14, Longest: 14, Freezes: MaxFreezes, LastDay: date(11)},
+			wantUpdate: Update{Extended: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, update := Record(tc.state, tc.today)
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.wantUpdate, update)
+		})
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	require.Equal(t, int32(0), Current(State{}, date(10)))
+	require.Equal(t, int32(4), Current(State{Count: 4, LastDay: date(10)}, date(10)))
+	require.Equal(t, int32(4), Current(State{Count: 4, LastDay: date(10)}, date(11)))
+	require.Equal(t, int32(0), Current(State{Count: 4, LastDay: date(10)}, date(12)))
+	require.Equal(t, int32(4), Current(State{Count: 4, Freezes: 1, LastDay: date(10)}, date(12)))
+}
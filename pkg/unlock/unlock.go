@@ -0,0 +1,83 @@
+// Package unlock works out which lessons of a course a learner can take. Lessons
+// unlock in lesson_order once the previous lesson is completed with a high enough
+// score, and passing a checkpoint lesson tests out of every lesson before it.
+package unlock
+
+// DefaultMinScore is the average score needed on the previous lesson for lessons without an unlock_score
+const DefaultMinScore = 80
+
+// Statuses of a lesson on a learner's course map
+const (
+	StatusLocked    = "locked"
+	StatusUnlocked  = "unlocked"
+	StatusCompleted = "completed"
+)
+
+// Lesson is a learner's progress on one lesson of a course
+type Lesson struct {
+	// Override is the admin's is_unlocked flag, which opens the lesson to every learner
+	Override bool
+	// Checkpoint lessons can always be taken, and passing one unlocks every lesson up to it
+	Checkpoint bool
+	// MinScore is the average score needed on the previous lesson to unlock this one,
+	// and on this lesson to pass it if it's a checkpoint. Nil means DefaultMinScore.
+	MinScore *int32
+	// Exercises is how many exercises the lesson has
+	Exercises int64
+	// Completed is how many of the lesson's exercises the learner has completed
+	Completed int64
+	// TotalScore is the sum of the learner's best scores on the lesson's exercises
+	TotalScore int64
+}
+
+// Score is the learner's average best score across the lesson's exercises
+func (l Lesson) Score() int32 {
+	if l.Exercises == 0 {
+		return 0
+	}
+	return int32(l.TotalScore / l.Exercises)
+}
+
+// Done reports whether the learner has completed every exercise in the lesson
+func (l Lesson) Done() bool {
+	return l.Exercises > 0 && l.Completed >= l.Exercises
+}
+
+func (l Lesson) minScore() int32 {
+	if l.MinScore == nil {
+		return DefaultMinScore
+	}
+	return *l.MinScore
+}
+
+// passed reports whether the lesson is completed with at least min as its score.
+// A lesson without exercises has nothing to pass.
+func (l Lesson) passed(min int32) bool {
+	return l.Exercises == 0 || (l.Done() && l.Score() >= min)
+}
+
+// Statuses returns the status of each lesson, which must be in lesson_order.
+// The first lesson is always unlocked.
+func Statuses(lessons []Lesson) []string {
+	testedOut := -1
+	for i, l := range lessons {
+		if l.Checkpoint && l.Done() && l.passed(l.minScore()) {
+			testedOut = i
+		}
+	}
+
+	statuses := make([]string, len(lessons))
+	for i, l := range lessons {
+		switch {
+		case l.Done():
+			statuses[i] = StatusCompleted
+		case i == 0 || i <= testedOut || l.Override || l.Checkpoint:
+			statuses[i] = StatusUnlocked
+		case statuses[i-1] != StatusLocked && lessons[i-1].passed(l.minScore()):
+			statuses[i] = StatusUnlocked
+		default:
+			statuses[i] = StatusLocked
+		}
+	}
+	return statuses
+}
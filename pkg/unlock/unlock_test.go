@@ -0,0 +1,98 @@
+package unlock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func score(n int32) *int32 {
+	return &n
+}
+
+// done is a lesson of two exercises completed with the given scores
+func done(a, b int64) Lesson {
+	return Lesson{Exercises: 2, Completed: 2, TotalScore: a + b}
+}
+
+func TestStatuses(t *testing.T) {
+	untouched := Lesson{Exercises: 2}
+
+	testCases := []struct {
+		name    string
+		lessons []Lesson
+		want    []string
+	}{
+		{
+			name:    "NewLearner",
+			lessons: []Lesson{untouched, untouched, untouched},
+			want:    []string{StatusUnlocked, StatusLocked, StatusLocked},
+		},
+		{
+			name:    "PreviousLessonPassed",
+			lessons: []Lesson{done(100, 80), untouched, untouched},
+			want:    []string{StatusCompleted, StatusUnlocked, StatusLocked},
+		},
+		{
+			name:    "PreviousLessonScoreTooLow",
+			lessons: []Lesson{done(100, 80), {Exercises: 2, MinScore: score(95)}},
+			want:    []string{StatusCompleted, StatusLocked},
+		},
+		{
+			name:    "PreviousLessonPartlyDone",
+			lessons: []Lesson{{Exercises: 2, Completed: 1, TotalScore: 100}, untouched},
+			want:    []string{StatusUnlocked, StatusLocked},
+		},
+		{
+			name:    "AdminOverride",
+			lessons: []Lesson{untouched, untouched, {Exercises: 2, Override: true}},
+			want:    []string{StatusUnlocked, StatusLocked, StatusUnlocked},
+		},
+		{
+			name:    "CheckpointAlwaysAvailable",
+			lessons: []Lesson{untouched, untouched, {Exercises: 2, Checkpoint: true}, untouched},
+			want:    []string{StatusUnlocked, StatusLocked, StatusUnlocked, StatusLocked},
+		},
+		{
+			name: "TestedOut",
+			lessons: []Lesson{
+				untouched,
+				untouched,
+				{Exercises: 2, Completed: 2, TotalScore: 190, Checkpoint: true},
+				untouched,
+				untouched,
+			},
+			want: []string{StatusUnlocked, StatusUnlocked, StatusCompleted, StatusUnlocked, StatusLocked},
+		},
+		{
+			name: "CheckpointFailed",
+			lessons: []Lesson{
+				untouched,
+				untouched,
+				{Exercises: 2, Completed: 2, TotalScore: 160, Checkpoint: true, MinScore: score(90)},
+			},
+			want: []string{StatusUnlocked, StatusLocked, StatusCompleted},
+		},
+		{
+			name:    "EmptyLessonDoesNotBlock",
+			lessons: []Lesson{done(90, 90), {}, untouched},
+			want:    []string{StatusCompleted, StatusUnlocked, StatusUnlocked},
+		},
+		{
+			name:    "LockedEmptyLessonStillBlocks",
+			lessons: []Lesson{untouched, {}, untouched},
+			want:    []string{StatusUnlocked, StatusLocked, StatusLocked},
+		},
+		{
+			name:    "CompletedStaysCompleted",
+			lessons: []Lesson{untouched, done(100, 100)},
+			want:    []string{StatusUnlocked, StatusCompleted},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Statuses(tc.lessons))
+		})
+	}
+}
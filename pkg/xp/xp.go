@@ -0,0 +1,121 @@
+// Package xp decides which XP awards a learner has earned. Every award has an
+// idempotency key, so recording the same award twice only counts it once.
+package xp
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sources of XP, as stored in the XP ledger
+const (
+	SourceLessonCompletion = "lesson_completion"
+	SourcePerfectScore     = "perfect_score"
+	SourceStreakBonus      = "streak_bonus"
+)
+
+// Periods the XP history can be grouped by. They match Postgres' date_trunc fields.
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+)
+
+const (
+	// DefaultLessonReward is awarded for lessons without an xp_reward
+	DefaultLessonReward = 10
+	// PerfectScoreBonus is awarded for scoring 100 on every exercise in a lesson
+	PerfectScoreBonus = 5
+	// StreakBonusEvery is how many streak days earn a streak bonus
+	StreakBonusEvery = 7
+	// StreakBonus is awarded each time the streak reaches a multiple of StreakBonusEvery
+	StreakBonus = 20
+)
+
+// Award is an amount of XP earned once per Key
+type Award struct {
+	Source string
+	Amount int32
+	Key    string
+}
+
+// LessonProgress is a learner's progress through a single lesson
+type LessonProgress struct {
+	// Reward is the lesson's xp_reward, or nil if it has none
+	Reward    *int32
+	Exercises int64
+	Completed int64
+	Perfect   int64
+}
+
+// ForLesson returns the awards earned so far on the lesson: its XP reward once
+// every exercise is completed, and a bonus once every exercise scores 100.
+func ForLesson(lessonID string, p LessonProgress) []Award {
+	if p.Exercises == 0 || p.Completed < p.Exercises {
+		return nil
+	}
+
+	reward := int32(DefaultLessonReward)
+	if p.Reward != nil {
+		reward = *p.Reward
+	}
+
+	var awards []Award
+	if reward > 0 {
+		awards = append(awards, Award{
+			Source: SourceLessonCompletion,
+			Amount: reward,
+			Key:    fmt.Sprintf("%s:%s", SourceLessonCompletion, lessonID),
+		})
+	}
+	if p.Perfect >= p.Exercises {
+		awards = append(awards, Award{
+			Source: SourcePerfectScore,
+			Amount: PerfectScoreBonus,
+			Key:    fmt.Sprintf("%s:%s", SourcePerfectScore, lessonID),
+		})
+	}
+	return awards
+}
+
+// ForStreak returns the streak bonus for a streak of count days extended on
+// day, if count has reached a multiple of StreakBonusEvery
+func ForStreak(count int32, day time.Time) (Award, bool) {
+	if count <= 0 || count%StreakBonusEvery != 0 {
+		return Award{}, false
+	}
+	return Award{
+		Source: SourceStreakBonus,
+		Amount: StreakBonus,
+		Key:    fmt.Sprintf("%s:%s", SourceStreakBonus, day.Format(time.DateOnly)),
+	}, true
+}
+
+// ValidPeriod reports whether period is one the XP history can be grouped by
+func ValidPeriod(period string) bool {
+	return period == PeriodDay || period == PeriodWeek
+}
+
+// PeriodStart returns the first day of the period containing day. Weeks start
+// on Monday, as they do for date_trunc.
+func PeriodStart(day time.Time, period string) time.Time {
+	if period != PeriodWeek {
+		return day
+	}
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Periods returns the starts of the n periods ending with the one containing
+// day, most recent first
+func Periods(day time.Time, period string, n int) []time.Time {
+	start := PeriodStart(day, period)
+	starts := make([]time.Time, 0, n)
+	for i := 0; i < n; i++ {
+		if period == PeriodWeek {
+			starts = append(starts, start.AddDate(0, 0, -7*i))
+		} else {
+			starts = append(starts, start.AddDate(0, 0, -i))
+		}
+	}
+	return starts
+}
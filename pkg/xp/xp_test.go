@@ -0,0 +1,86 @@
+package xp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const lessonID = "5b0f5f4e-8f3a-4c43-9a55-2f1f4f9d3c11"
+
+func date(day int) time.Time {
+	return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+}
+
+func reward(n int32) *int32 {
+	return &n
+}
+
+func TestForLesson(t *testing.T) {
+	testCases := []struct {
+		name     string
+		progress LessonProgress
+		want     []Award
+	}{
+		{
+			name:     "Incomplete",
+			progress: LessonProgress{Reward: reward(15), Exercises: 3, Completed: 2, Perfect: 2},
+		},
+		{
+			name:     "NoExercises",
+			progress: LessonProgress{Reward: reward(15)},
+		},
+		{
+			name:     "Completed",
+			progress: LessonProgress{Reward: reward(15), Exercises: 3, Completed: 3, Perfect: 2},
+			want: []Award{
+				{Source: SourceLessonCompletion, Amount: 15, Key: "lesson_completion:" + lessonID},
+			},
+		},
+		{
+			name:     "Perfect",
+			progress: LessonProgress{Reward: reward(15), Exercises: 3, Completed: 3, Perfect: 3},
+			want: []Award{
+				{Source: SourceLessonCompletion, Amount: 15, Key: "lesson_completion:" + lessonID},
+				{Source: SourcePerfectScore, Amount: PerfectScoreBonus, Key: "perfect_score:" + lessonID},
+			},
+		},
+		{
+			name:     "DefaultReward",
+			progress: LessonProgress{Exercises: 1, Completed: 1},
+			want: []Award{
+				{Source: SourceLessonCompletion, Amount: DefaultLessonReward, Key: "lesson_completion:" + lessonID},
+			},
+		},
+		{
+			name:     "ZeroReward",
+			progress: LessonProgress{Reward: reward(0), Exercises: 1, Completed: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, ForLesson(lessonID, tc.progress))
+		})
+	}
+}
+
+func TestForStreak(t *testing.T) {
+	_, ok := ForStreak(6, date(10))
+	require.False(t, ok)
+	_, ok = ForStreak(0, date(10))
+	require.False(t, ok)
+
+	award, ok := ForStreak(14, date(10))
+	require.True(t, ok)
+	require.Equal(t, Award{Source: SourceStreakBonus, Amount: StreakBonus, Key: "streak_bonus:2025-03-10"}, award)
+}
+
+func TestPeriods(t *testing.T) {
+	// 12 March 2025 is a Wednesday
+	require.Equal(t, []time.Time{date(12), date(11), date(10)}, Periods(date(12), PeriodDay, 3))
+	require.Equal(t, []time.Time{date(10), date(3)}, Periods(date(12), PeriodWeek, 2))
+	require.Equal(t, date(10), PeriodStart(date(16), PeriodWeek))
+	require.Equal(t, date(10), PeriodStart(date(10), PeriodWeek))
+}
@@ -1,13 +1,28 @@
 package utils
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
-	DBSource     string `mapstructure:"DB_SOURCE"`
-	ServerAddr   string `mapstructure:"SERVER_ADDR"`
-	GmailKey     string `mapstructure:"GMAIL_KEY"`
-	EmailAddr    string `mapstructure:"EMAIL_ADDR"`
-  	PasetoSecret string `mapstructure:"PASETO_SECRET"` 
+	DBSource                   string        `mapstructure:"DB_SOURCE"`
+	ServerAddr                 string        `mapstructure:"SERVER_ADDR"`
+	GmailKey                   string        `mapstructure:"GMAIL_KEY"`
+	EmailAddr                  string        `mapstructure:"EMAIL_ADDR"`
+	PasetoSecret               string        `mapstructure:"PASETO_SECRET"`
+	TokenClockSkew             time.Duration `mapstructure:"TOKEN_CLOCK_SKEW"`
+	TokenMaker                 string        `mapstructure:"TOKEN_MAKER"`
+	PasetoKeyID                string        `mapstructure:"PASETO_KEY_ID"`
+	PasetoPrivateKeyFile       string        `mapstructure:"PASETO_PRIVATE_KEY_FILE"`
+	PasetoPublicKeyFiles       string        `mapstructure:"PASETO_PUBLIC_KEY_FILES"`
+	ClientURL                  string        `mapstructure:"CLIENT_URL"`
+	RequireEmailVerification   bool          `mapstructure:"REQUIRE_EMAIL_VERIFICATION"`
+	PasswordMinLength          int           `mapstructure:"PASSWORD_MIN_LENGTH"`
+	BreachedPasswordsFile      string        `mapstructure:"BREACHED_PASSWORDS_FILE"`
+	Admin2FARequiredRoles      string        `mapstructure:"ADMIN_2FA_REQUIRED_ROLES"`
+	LeaderboardRefreshInterval time.Duration `mapstructure:"LEADERBOARD_REFRESH_INTERVAL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
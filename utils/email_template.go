@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var emailTemplates = template.Must(template.ParseFS(templateFS, "templates/*.html"))
+
+// RenderEmail executes the named HTML email template (e.g. "password_reset.html") with data
+func RenderEmail(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := emailTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	defaultPasswordMinLength = 8
+	// bcrypt ignores everything after the first 72 bytes
+	passwordMaxLength = 72
+)
+
+var ErrBreachedPassword = errors.New("password has appeared in a data breach, please choose another one")
+
+// PasswordPolicy decides whether a new password is acceptable
+type PasswordPolicy struct {
+	minLength int
+	breached  map[string]struct{}
+}
+
+// NewPasswordPolicy creates a policy requiring at least minLength characters
+// (8 if minLength is 0). If breachedListFile is set, passwords listed in it,
+// one per line, are rejected regardless of case.
+func NewPasswordPolicy(minLength int, breachedListFile string) (*PasswordPolicy, error) {
+	if minLength < 0 {
+		return nil, fmt.Errorf("Invalid password min length: must not be negative")
+	}
+	if minLength == 0 {
+		minLength = defaultPasswordMinLength
+	}
+
+	policy := &PasswordPolicy{
+		minLength: minLength,
+		breached:  map[string]struct{}{},
+	}
+	if breachedListFile == "" {
+		return policy, nil
+	}
+
+	file, err := os.Open(breachedListFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		policy.breached[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate returns an error describing why the password doesn't meet the policy
+func (p *PasswordPolicy) Validate(password string) error {
+	if len([]rune(password)) < p.minLength {
+		return fmt.Errorf("password must be at least %d characters long", p.minLength)
+	}
+	if len(password) > passwordMaxLength {
+		return fmt.Errorf("password must be at most %d bytes long", passwordMaxLength)
+	}
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		return ErrBreachedPassword
+	}
+	return nil
+}
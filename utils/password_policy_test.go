@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	listFile := filepath.Join(t.TempDir(), "breached.txt")
+	err := os.WriteFile(listFile, []byte("# common passwords\npassword123\n\nLetMeIn2024\n"), 0o600)
+	require.NoError(t, err)
+
+	policy, err := NewPasswordPolicy(10, listFile)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "Valid", password: "correct horse battery"},
+		{name: "TooShort", password: "short1", wantErr: true},
+		{name: "TooLong", password: strings.Repeat("a", 73), wantErr: true},
+		{name: "Breached", password: "password123", wantErr: true},
+		{name: "BreachedDifferentCase", password: "letmein2024", wantErr: true},
+		{name: "MultiByteCountsCharacters", password: "ñññññññññññ"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.Validate(tc.password)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewPasswordPolicy(t *testing.T) {
+	policy, err := NewPasswordPolicy(0, "")
+	require.NoError(t, err)
+	require.Error(t, policy.Validate("1234567"))
+	require.NoError(t, policy.Validate("12345678"))
+
+	_, err = NewPasswordPolicy(-1, "")
+	require.Error(t, err)
+
+	_, err = NewPasswordPolicy(8, filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
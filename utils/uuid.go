@@ -1,16 +1,14 @@
 package utils
 
 import (
-	"log"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-
+// StringToPgTypeUUID parses a UUID from user input, returning an error if it is malformed
 func StringToPgTypeUUID(uuidStr string) (pgtype.UUID, error) {
 	uuidVer, err := uuid.Parse(uuidStr)
 	if err != nil {
-		log.Fatalf("Failed to parse UUID: %v", err)
 		return pgtype.UUID{}, err
 	}
 